@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/bulk"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// defaultRequestRate is the number of requests per second bulk commands send
+// to leetcode on average
+const defaultRequestRate = 4
+
+// bulkClient returns an authenticated client sharing the request rate limit
+// of the `rate` flag across all of its requests
+func bulkClient(cmd *cobra.Command) (*api.Client, error) {
+	rate, _ := cmd.Flags().GetFloat64("rate")
+	workers, _ := cmd.Flags().GetInt("workers")
+	return api.GetAuthClient(api.Throttle(bulk.NewLimiter(rate, workers)))
+}
+
+// bulkProblems returns the slug of the problem selected by the `id` flag of
+// cmd, or with the `all` flag those of every problem with the given status
+func bulkProblems(cmd *cobra.Command, client *api.Client, status string) ([]string, error) {
+	id, _ := cmd.Flags().GetInt("id")
+	all, _ := cmd.Flags().GetBool("all")
+	if !all {
+		status = "all"
+	}
+
+	problemCollection, err := client.GetProblemCollection("all", "", "", "all", status)
+	if err != nil {
+		return nil, err
+	}
+
+	var slugs []string
+	for _, problem := range problemCollection.Problems {
+		if all || problem.Stat.FrontendQuestionID == id {
+			slugs = append(slugs, problem.Stat.QuestionTitleSlug)
+		}
+	}
+	if len(slugs) == 0 && !all {
+		return nil, fmt.Errorf("Failed to find problem with ID %d", id)
+	}
+	return slugs, nil
+}
+
+// bulkOptions reads the bulk engine options from the flags of cmd, drawing a
+// progress bar when stderr is a terminal
+func bulkOptions(cmd *cobra.Command) bulk.Options {
+	workers, _ := cmd.Flags().GetInt("workers")
+	opts := bulk.Options{
+		Workers: workers,
+		Output:  cmd.OutOrStdout(),
+	}
+	if f, ok := cmd.ErrOrStderr().(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		opts.Progress = f
+	}
+	return opts
+}
+
+// bulkSummary reports the failures of a bulk run once it is over, returning
+// an error when some jobs did not complete
+func bulkSummary(cmd *cobra.Command, verb string, report *bulk.Report, err error) error {
+	if report == nil {
+		return err
+	}
+
+	w := cmd.ErrOrStderr()
+	if len(report.Failures) > 0 {
+		fmt.Fprintf(w, "\n%s\n", utils.Red(fmt.Sprintf("%d failure(s)", len(report.Failures))))
+		for _, f := range report.Failures {
+			fmt.Fprintf(w, "%s: %v\n", f.Key, f.Err)
+		}
+	}
+
+	summary := fmt.Sprintf("%s %d of %d", verb, report.Done+report.Resumed, report.Total)
+	if report.Resumed > 0 {
+		summary += fmt.Sprintf(", %d by a previous run", report.Resumed)
+	}
+	if report.Total > 1 {
+		fmt.Fprintf(w, "\n%s\n", summary)
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return fmt.Errorf("interrupted, run the same command again to resume")
+	case err != nil:
+		return err
+	case len(report.Failures) > 0:
+		return fmt.Errorf("%d of %d failed, run the same command again to retry them", len(report.Failures), report.Total)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/bulk"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	RootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheWarmCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+
+	cacheWarmCmd.Flags().IntP("id", "i", 0, "ID of problem to be cached")
+	cacheWarmCmd.Flags().Bool("all", false, "cache every problem")
+	cacheWarmCmd.Flags().Bool("refresh", false, "fetch again the problems already cached")
+	cacheWarmCmd.Flags().Int("workers", bulk.DefaultWorkers, "number of problems fetched concurrently")
+	cacheWarmCmd.Flags().Float64("rate", defaultRequestRate, "maximum requests per second to leetcode, 0 disables the limit")
+	cacheWarmCmd.Flags().Bool("restart", false, "fetch every problem again instead of resuming an interrupted --all")
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   `cache <commands>`,
+	Short: `Manage the problem cache`,
+	Long: `Manage the local cache of problem details, stored in the data directory
+and used for a week by every command reading problems`,
+}
+
+var cacheWarmCmd = &cobra.Command{
+	Use:   `warm`,
+	Short: `Fetch problems into the cache`,
+	Long: `Fetch the details of problems missing from the cache or older than a
+week. An interrupted or partially failed --all resumes with the remaining
+problems when run again.`,
+	Args: arg.CacheWarm,
+	RunE: cacheWarm,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   `clear`,
+	Short: `Remove every cached problem`,
+	Args:  arg.CacheClear,
+	RunE:  cacheClear,
+}
+
+func cacheWarm(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	refresh, _ := cmd.Flags().GetBool("refresh")
+	restart, _ := cmd.Flags().GetBool("restart")
+
+	client, err := bulkClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	slugs, err := bulkProblems(cmd, client, "all")
+	if err != nil {
+		return err
+	}
+
+	var jobs []bulk.Job
+	for _, slug := range slugs {
+		slug := slug
+		jobs = append(jobs, bulk.Job{
+			Key: slug,
+			Run: func(ctx context.Context) (string, error) {
+				fetched, err := client.WarmProblemDetail(ctx, slug, refresh)
+				if err != nil || !fetched {
+					return "", err
+				}
+				return fmt.Sprintf("%s %s", utils.Green("cached"), slug), nil
+			},
+		})
+	}
+
+	opts := bulkOptions(cmd)
+	if all {
+		opts.StatePath = filepath.Join(utils.BulkStateDir, "cache-warm-all")
+		if restart {
+			os.Remove(opts.StatePath)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := bulk.Run(ctx, jobs, opts)
+	return bulkSummary(cmd, "cached", report, err)
+}
+
+func cacheClear(cmd *cobra.Command, args []string) error {
+	err := api.ClearProblemCache()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), "cleared the problem cache")
+	return nil
+}
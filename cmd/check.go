@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// defaultJudgeTimeout bounds how long a command waits for the judge verdict
+const defaultJudgeTimeout = 5 * time.Minute
+
+func init() {
+	RootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().StringP("output", "o", "text", "output format: {text|json}")
+	checkCmd.Flags().Bool("stdout-only", false, "print nothing but the stdout of the solution")
+	checkCmd.Flags().Int("stdout-limit", defaultStdoutLimit, "maximum stdout lines shown per test case, 0 shows everything")
+	checkCmd.Flags().Duration("timeout", defaultJudgeTimeout, "maximum time to wait for the judge result")
+}
+
+var checkCmd = &cobra.Command{
+	Use:   `check <submission-id>`,
+	Short: `Check judge result`,
+	Long:  `Check the judge result of a previous submission or interpretation`,
+	Args:  arg.Check,
+	RunE:  check,
+}
+
+func check(cmd *cobra.Command, args []string) error {
+	ctx, cancel := judgeContext(cmd)
+	defer cancel()
+
+	client, err := api.GetAuthClient()
+	if err != nil {
+		return err
+	}
+
+	if api.IsInterpretID(args[0]) {
+		result, err := client.CheckInterpretation(ctx, args[0])
+		if err != nil {
+			return pendingHint(err)
+		}
+		err = outputInterpretation(cmd, result, "")
+		if err != nil {
+			return err
+		}
+		return verdictError(result.Verdict())
+	}
+
+	result, err := client.CheckSubmission(ctx, args[0])
+	if err != nil {
+		return pendingHint(err)
+	}
+	resolveSubmission(cmd, result)
+	err = outputSubmission(cmd, result, "")
+	if err != nil {
+		return err
+	}
+	return verdictError(result.Verdict())
+}
+
+// judgeContext returns a context cancelled on SIGINT or once the `timeout`
+// flag of cmd elapses, printing the waits of retried requests on stderr
+func judgeContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	ctx = api.WithRetryNotify(ctx, func(n api.RetryNotice) {
+		fmt.Fprintf(cmd.ErrOrStderr(), "%s\n", utils.Yellow(n.String()))
+	})
+	return ctx, func() {
+		cancel()
+		stop()
+	}
+}
+
+// pendingHint explains how to fetch a judge result later when polling was
+// interrupted before the verdict was known
+func pendingHint(err error) error {
+	var pe *api.PendingError
+	if !errors.As(err, &pe) {
+		return err
+	}
+	return &pendingHintError{pe}
+}
+
+type pendingHintError struct {
+	*api.PendingError
+}
+
+func (e *pendingHintError) Error() string {
+	reason := "interrupted"
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		reason = "timed out"
+	}
+	return fmt.Sprintf(
+		"%s while waiting for the judge, %s is still being judged\nrun `lc check %s` to fetch its result",
+		reason,
+		e.ID,
+		e.ID,
+	)
+}
+
+func (e *pendingHintError) Unwrap() error {
+	return e.PendingError
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+)
+
+// Exit codes of the cli, scripts can rely on them to act on judge verdicts
+const (
+	ExitOK            = 0 // command succeeded, solution accepted
+	ExitError         = 1 // any other failure
+	ExitUsage         = 2 // invalid command, flags or arguments
+	ExitWrongAnswer   = 3 // solution rejected with wrong answer
+	ExitCompileError  = 4 // solution failed to compile
+	ExitRuntimeError  = 5 // solution crashed at runtime
+	ExitLimitExceeded = 6 // solution exceeded time, memory or output limit
+	ExitAuth          = 7 // missing or expired leetcode session
+	ExitNetwork       = 8 // leetcode could not be reached
+	ExitPending       = 9 // judge did not finish before timeout or interrupt
+)
+
+// VerdictError is returned by commands whose solution was not accepted,
+// the verdict has already been rendered
+type VerdictError struct {
+	Verdict api.Verdict
+}
+
+func (e *VerdictError) Error() string {
+	return fmt.Sprintf("solution rejected: %s", e.Verdict)
+}
+
+// verdictError returns a VerdictError unless v is accepted
+func verdictError(v api.Verdict) error {
+	if v == api.VerdictAccepted {
+		return nil
+	}
+	return &VerdictError{Verdict: v}
+}
+
+// IsUsageError reports whether err was caused by invalid command usage
+func IsUsageError(err error) bool {
+	var fe *arg.FlagError
+	return errors.As(err, &fe) || strings.HasPrefix(err.Error(), "unknown command ")
+}
+
+// ExitCode maps the error returned by a command to the process exit code
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var ve *VerdictError
+	if errors.As(err, &ve) {
+		switch ve.Verdict {
+		case api.VerdictWrongAnswer:
+			return ExitWrongAnswer
+		case api.VerdictCompileError:
+			return ExitCompileError
+		case api.VerdictRuntimeError:
+			return ExitRuntimeError
+		case api.VerdictTimeLimitExceeded, api.VerdictMemoryLimitExceeded, api.VerdictOutputLimitExceeded:
+			return ExitLimitExceeded
+		default:
+			return ExitError
+		}
+	}
+
+	if IsUsageError(err) {
+		return ExitUsage
+	}
+
+	var pe *api.PendingError
+	if errors.As(err, &pe) {
+		return ExitPending
+	}
+
+	var ae *api.AuthError
+	var he *api.HTTPError
+	if errors.As(err, &ae) || (errors.As(err, &he) && he.IsAuthFailure()) {
+		return ExitAuth
+	}
+
+	// an interrupted request is not a network failure, while a request timing
+	// out is; file errors also implement net.Error and are neither
+	if errors.Is(err, context.Canceled) {
+		return ExitError
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ExitNetwork
+	}
+	var ue *url.Error
+	var oe *net.OpError
+	if errors.As(err, &ue) || errors.As(err, &oe) {
+		return ExitNetwork
+	}
+
+	return ExitError
+}
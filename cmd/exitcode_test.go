@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+)
+
+func TestExitCode(t *testing.T) {
+	_, missing := os.Open("missing.go")
+	dial := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	for _, tt := range []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"missing file", missing, ExitError},
+		{"wrapped missing file", fmt.Errorf("cannot read test file: %w", missing), ExitError},
+		{"wrong answer", verdictError(api.VerdictWrongAnswer), ExitWrongAnswer},
+		{"compile error", verdictError(api.VerdictCompileError), ExitCompileError},
+		{"runtime error", verdictError(api.VerdictRuntimeError), ExitRuntimeError},
+		{"time limit", verdictError(api.VerdictTimeLimitExceeded), ExitLimitExceeded},
+		{"usage", &arg.FlagError{Err: errors.New("bad flag")}, ExitUsage},
+		{"pending", &api.PendingError{ID: "1", Err: context.DeadlineExceeded}, ExitPending},
+		{"auth", &api.AuthError{Err: errors.New("no session")}, ExitAuth},
+		{"dial", dial, ExitNetwork},
+		{"request", &url.Error{Op: "Get", URL: "https://leetcode.com", Err: dial}, ExitNetwork},
+		{"interrupted request", &url.Error{Op: "Get", URL: "https://leetcode.com", Err: context.Canceled}, ExitError},
+		{"timed out request", &url.Error{Op: "Get", URL: "https://leetcode.com", Err: context.DeadlineExceeded}, ExitNetwork},
+	} {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("%s: ExitCode(%v) = %d, want %d", tt.name, tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/bulk"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().IntP("id", "i", 0, "ID of problem to be exported")
+	exportCmd.Flags().Bool("all", false, "export every problem")
+	exportCmd.Flags().StringP("lang", "l", "", "also export the code template in a language, as slug, name or file extension")
+	exportCmd.Flags().Int("workers", bulk.DefaultWorkers, "number of problems exported concurrently")
+	exportCmd.Flags().Float64("rate", defaultRequestRate, "maximum requests per second to leetcode, 0 disables the limit")
+	exportCmd.Flags().Bool("restart", false, "export every problem again instead of resuming an interrupted --all")
+}
+
+var exportCmd = &cobra.Command{
+	Use:   `export`,
+	Short: `Export problem descriptions into the workspace`,
+	Long: `Write the markdown description of problems given by the markdown
+template, and with --lang their code template to the source file given by
+the sourceCodePath template unless it exists already.
+
+Problem details are read from the problem cache, see 'lc cache'. An
+interrupted or partially failed --all resumes with the remaining problems
+when run again.`,
+	Args: arg.Export,
+	RunE: export,
+}
+
+func export(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	lang, _ := cmd.Flags().GetString("lang")
+	restart, _ := cmd.Flags().GetBool("restart")
+
+	client, err := bulkClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	slugs, err := bulkProblems(cmd, client, "all")
+	if err != nil {
+		return err
+	}
+
+	var jobs []bulk.Job
+	for _, slug := range slugs {
+		slug := slug
+		jobs = append(jobs, bulk.Job{
+			Key: slug,
+			Run: func(ctx context.Context) (string, error) {
+				return exportProblem(ctx, client, slug, lang)
+			},
+		})
+	}
+
+	opts := bulkOptions(cmd)
+	if all {
+		opts.StatePath = filepath.Join(utils.BulkStateDir, strings.TrimSuffix("export-all-"+lang, "-"))
+		if restart {
+			os.Remove(opts.StatePath)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := bulk.Run(ctx, jobs, opts)
+	return bulkSummary(cmd, "exported", report, err)
+}
+
+// exportProblem writes the description of problem slug, with its code
+// template in lang when not empty, until ctx is done
+func exportProblem(ctx context.Context, client *api.Client, slug string, lang string) (string, error) {
+	problemDetail, err := client.GetProblemDetailBySlug(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+
+	markdownPath, sourceCodePath, err := problemDetail.ExportDescription(lang)
+	if err != nil {
+		return "", err
+	}
+	if sourceCodePath == "" {
+		return fmt.Sprintf("%s %s", utils.Green("exported"), markdownPath), nil
+	}
+	return fmt.Sprintf("%s %s, %s", utils.Green("exported"), markdownPath, sourceCodePath), nil
+}
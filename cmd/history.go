@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/journal"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	RootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().IntP("id", "i", 0, "ID of problem whose runs are listed")
+	historyCmd.Flags().String("since", "", "list runs younger than an age like 7d or 12h, or since a date like 2006-01-02")
+	historyCmd.Flags().String("verdict", "", "list runs with a verdict, like ac, wa, tle or compile_error")
+	historyCmd.Flags().String("kind", "all", "kind of runs listed: {all|submission|interpretation}")
+	historyCmd.Flags().IntP("limit", "n", 0, "list only the latest runs, 0 lists all of them")
+	historyCmd.Flags().StringP("output", "o", "text", "output format: {text|json}")
+}
+
+var historyCmd = &cobra.Command{
+	Use:     `history`,
+	Aliases: []string{`hist`},
+	Short:   `List past submissions and interpretations`,
+	Long: `List the submissions and interpretations recorded in the local journal,
+journal.jsonl in the data directory, oldest first`,
+	Args: arg.History,
+	RunE: history,
+}
+
+func history(cmd *cobra.Command, args []string) error {
+	id, _ := cmd.Flags().GetInt("id")
+	since, _ := cmd.Flags().GetString("since")
+	verdict, _ := cmd.Flags().GetString("verdict")
+	kind, _ := cmd.Flags().GetString("kind")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	filter := journal.Filter{ProblemID: id}
+	if since != "" {
+		filter.Since, _ = journal.ParseSince(since, time.Now())
+	}
+	if verdict != "" {
+		filter.Verdict, _ = api.ParseVerdict(verdict)
+	}
+	if kind != "all" {
+		filter.Kind = kind
+	}
+
+	entries, err := journal.Load()
+	if err != nil {
+		return err
+	}
+	entries = journal.Select(entries, filter)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	if output, _ := cmd.Flags().GetString("output"); output == "json" {
+		if entries == nil {
+			entries = []journal.Entry{}
+		}
+		return writeJSON(cmd, entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "no run recorded")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tKIND\tPROBLEM\tLANG\tVERDICT\tRUNTIME\tMEMORY\tID")
+	for _, e := range entries {
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			e.Time.Local().Format("2006-01-02 15:04"),
+			e.Kind,
+			e.ProblemID,
+			e.Lang,
+			historyVerdict(e),
+			measure(e.Runtime, e.RuntimePercentile),
+			measure(e.Memory, e.MemoryPercentile),
+			e.SubmissionID,
+		)
+	}
+	return w.Flush()
+}
+
+// colorVerdict renders the abbreviation of v, green when accepted
+func colorVerdict(v api.Verdict) string {
+	if v == api.VerdictAccepted {
+		return utils.Green(v.Abbrev())
+	}
+	return utils.Red(v.Abbrev())
+}
+
+// historyVerdict renders the verdict of e, submissions whose verdict was
+// never fetched being pending
+func historyVerdict(e journal.Entry) string {
+	if e.Kind == journal.KindSubmission && e.Verdict == api.VerdictUnknown {
+		return utils.Gray("pending")
+	}
+	return colorVerdict(e.Verdict)
+}
+
+// measure renders a runtime or memory usage along with its percentile
+func measure(display string, percentile *float32) string {
+	if display == "" {
+		return "-"
+	}
+	if percentile == nil {
+		return display
+	}
+	return fmt.Sprintf("%s (%.1f%%)", display, *percentile)
+}
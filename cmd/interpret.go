@@ -1,34 +1,50 @@
 package cmd
 
 import (
-	"path/filepath"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
 
 	"github.com/ckidckidckid/leetcode-cli/pkg/api"
 	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/ckidckidckid/leetcode-cli/pkg/testcase"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	RootCmd.AddCommand(interpretCmd)
-	interpretCmd.PersistentFlags().IntP("id", "i", 0, "ID of problem to be submitted")
+	interpretCmd.PersistentFlags().IntP("id", "i", 0, "ID of problem to be submitted, inferred from the file by default")
 	interpretCmd.PersistentFlags().StringP("file", "f", "", "path of file to be submitted")
-	interpretCmd.PersistentFlags().StringP("test_input", "t", "", "test input to be submitted")
+	interpretCmd.PersistentFlags().StringP("test_input", "t", "", "test input to be submitted, `-` reads it from stdin")
+	interpretCmd.PersistentFlags().String("test_file", "", "path of file holding the test input to be submitted, `-` reads it from stdin")
+	interpretCmd.PersistentFlags().StringP("output", "o", "text", "output format: {text|json}")
+	interpretCmd.PersistentFlags().Bool("stdout-only", false, "print nothing but the stdout of the solution")
+	interpretCmd.PersistentFlags().Int("stdout-limit", defaultStdoutLimit, "maximum stdout lines shown per test case, 0 shows everything")
+	interpretCmd.PersistentFlags().Bool("print-bundle", false, "print the code sent to the judge, with local Go packages inlined, instead of sending it")
+	interpretCmd.PersistentFlags().Duration("timeout", defaultJudgeTimeout, "maximum time to wait for the judge result")
 }
 
 var interpretCmd = &cobra.Command{
-	Use:   `interpret`,
+	Use:   `interpret [file]`,
 	Short: `Interpret code`,
-	Long:  `Interpret local code to leetcode problem with testing input`,
-	Args:  arg.Interpret,
-	RunE:  interpret,
+	Long: `Interpret local code to leetcode problem with testing input
+
+Test input defaults to the problem examples followed by the test cases
+stored with 'lc test add', interpreted in batches of at most 10. It is
+given one line per parameter, either raw or with literal '\n' separators
+on the command line.
+
+The problem ID and language are inferred from the file like 'lc submit'.`,
+	Args: arg.Interpret,
+	RunE: interpret,
 }
 
 func interpret(cmd *cobra.Command, args []string) error {
-	id, _ := cmd.Flags().GetInt("id")
-	file, _ := cmd.Flags().GetString("file")
-	testInput, _ := cmd.Flags().GetString("test_input")
-
-	fp, err := filepath.Abs(file)
+	fp, id, err := resolveSource(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -43,15 +59,200 @@ func interpret(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if printBundle, _ := cmd.Flags().GetBool("print-bundle"); printBundle {
+		return printSourceCode(cmd, problemDetail, fp)
+	}
+
+	testCases, err := interpretInput(cmd, problemDetail)
+	if err != nil {
+		return err
+	}
+
 	sClient, err := api.GetSubmitClient(problemDetail)
 	if err != nil {
 		return err
 	}
 
-	err = sClient.InterpretCode(problemDetail, fp, testInput)
+	ctx, cancel := judgeContext(cmd)
+	defer cancel()
+
+	result, err := interpretBatches(ctx, sClient, problemDetail, fp, testCases)
+	if err != nil {
+		return pendingHint(err)
+	}
+	err = expectStored(problemDetail, result)
+	if err != nil {
+		return err
+	}
+	recordInterpretation(cmd, problemDetail, fp, result)
+
+	err = outputInterpretation(cmd, result, fp)
 	if err != nil {
 		return err
 	}
+	return verdictError(result.Verdict())
+}
+
+// interpretInput resolves the test cases from the `test_input` or `test_file`
+// flags, defaulting to the problem examples and the stored test cases
+func interpretInput(cmd *cobra.Command, pd *model.ProblemDetail) ([]string, error) {
+	input, err := readInput(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if input != "" {
+		return pd.SplitTestCases(input), nil
+	}
+
+	id, err := strconv.Atoi(pd.QuestionFrontendID)
+	if err != nil {
+		return nil, err
+	}
+	suite, err := testcase.Load(id)
+	if err != nil {
+		return nil, err
+	}
+
+	cases := pd.GetExampleTestCases()
+	seen := make(map[string]bool)
+	for _, input := range cases {
+		seen[input] = true
+	}
+	for _, stored := range suite.Inputs() {
+		for _, input := range pd.SplitTestCases(stored) {
+			if !seen[input] {
+				seen[input] = true
+				cases = append(cases, input)
+			}
+		}
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("problem %s has no example test case, provide one with 'test_input'", pd.QuestionFrontendID)
+	}
+	return cases, nil
+}
+
+// interpretBatches interprets testCases in as few judge requests as allowed,
+// stopping at the first batch that did not run to completion, which is then
+// returned alone with its FirstCase set
+func interpretBatches(ctx context.Context, c *api.Client, pd *model.ProblemDetail, fp string, testCases []string) (*api.InterpretResult, error) {
+	var results []*api.InterpretResult
+	for start := 0; start < len(testCases); start += api.MaxInterpretTestCases {
+		end := start + api.MaxInterpretTestCases
+		if end > len(testCases) {
+			end = len(testCases)
+		}
 
+		result, err := c.InterpretCode(ctx, pd, fp, testCases[start:end])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+
+		if v := result.Verdict(); v != api.VerdictAccepted && v != api.VerdictWrongAnswer {
+			result.FirstCase = start
+			return result, nil
+		}
+	}
+	return mergeInterpretResults(results), nil
+}
+
+// mergeInterpretResults combines the answers of successive batches into the
+// first result
+func mergeInterpretResults(results []*api.InterpretResult) *api.InterpretResult {
+	merged := *results[0]
+	merged.StdOutputList = batchStdout(results[0])
+	for _, r := range results[1:] {
+		merged.DataInput += "\n" + r.DataInput
+		merged.TestCases = append(merged.TestCases, r.TestCases...)
+		merged.CodeAnswer = append(merged.CodeAnswer, r.CodeAnswer...)
+		merged.ExpectedCodeAnswer = append(merged.ExpectedCodeAnswer, r.ExpectedCodeAnswer...)
+		merged.CodeOutput = append(merged.CodeOutput, r.CodeOutput...)
+		merged.StdOutputList = append(merged.StdOutputList, batchStdout(r)...)
+		merged.CorrectAnswer = merged.CorrectAnswer && r.CorrectAnswer
+		merged.CompareResult += r.CompareResult
+		merged.TotalCorrect += r.TotalCorrect
+		merged.TotalTestcases += r.TotalTestcases
+	}
+	return &merged
+}
+
+// expectStored sets the stored expected answers of the test cases of result
+// from the test case library of the problem
+func expectStored(pd *model.ProblemDetail, result *api.InterpretResult) error {
+	id, err := strconv.Atoi(pd.QuestionFrontendID)
+	if err != nil {
+		return err
+	}
+	suite, err := testcase.Load(id)
+	if err != nil {
+		return err
+	}
+
+	expected := make(map[string]string)
+	for _, c := range suite.Cases {
+		// the answer of a block holding several inputs belongs to none of them
+		if c.Expected != "" && len(pd.SplitTestCases(c.Input)) == 1 {
+			expected[c.Input] = c.Expected
+		}
+	}
+
+	result.StoredExpected = make([]string, len(result.TestCases))
+	for i, input := range result.TestCases {
+		result.StoredExpected[i] = expected[input]
+	}
 	return nil
 }
+
+// batchStdout returns the stdout list of a batch without the trailing entries
+// the judge adds past its test cases
+func batchStdout(r *api.InterpretResult) []string {
+	if len(r.StdOutputList) > len(r.TestCases) {
+		return r.StdOutputList[:len(r.TestCases)]
+	}
+	return r.StdOutputList
+}
+
+// readInput reads raw test input from the `test_input` or `test_file` flags,
+// either reading stdin when set to `-`; it returns an empty string when
+// neither is applied, and an error when the input they name is empty
+func readInput(cmd *cobra.Command) (string, error) {
+	testInput, _ := cmd.Flags().GetString("test_input")
+	testFile, _ := cmd.Flags().GetString("test_file")
+
+	var source string
+	var b []byte
+	var err error
+	switch {
+	case testInput == "-" || testFile == "-":
+		source = "stdin"
+		b, err = io.ReadAll(cmd.InOrStdin())
+	case testInput != "":
+		return normalizeInput(strings.ReplaceAll(testInput, "\\n", "\n")), nil
+	case testFile != "":
+		source = testFile
+		b, err = os.ReadFile(testFile)
+	default:
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	input := normalizeInput(string(b))
+	if input == "" {
+		return "", fmt.Errorf("no test input in %s", source)
+	}
+	return input, nil
+}
+
+// normalizeInput drops carriage returns and blank lines from raw test input
+func normalizeInput(input string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/journal"
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// recordPendingSubmission journals a submission as soon as it is posted, so
+// that it is known even when waiting for its verdict is interrupted
+func recordPendingSubmission(cmd *cobra.Command, id int, fp string, payload *api.SubmitPayload, submissionID string) journal.Entry {
+	e := journal.Entry{
+		Time:         time.Now().UTC(),
+		Kind:         journal.KindSubmission,
+		ProblemID:    id,
+		Lang:         payload.Lang,
+		File:         absPath(fp),
+		CodeHash:     journal.HashCode(payload.TypedCode),
+		SubmissionID: submissionID,
+	}
+	appendJournal(cmd, e)
+	return e
+}
+
+// recordSubmission journals the verdict of the pending submission e, a
+// failure to do so is only reported as the verdict matters more
+func recordSubmission(cmd *cobra.Command, e journal.Entry, sr *api.SubmissionResult) {
+	e.Time = time.Now().UTC()
+	e.Verdict = sr.Verdict()
+	e.Runtime = sr.StatusRuntime
+	e.Memory = sr.StatusMemory
+	if e.Verdict == api.VerdictAccepted {
+		e.RuntimePercentile = &sr.RuntimePercentile
+		e.MemoryPercentile = &sr.MemoryPercentile
+	}
+	appendJournal(cmd, e)
+}
+
+// resolveSubmission journals the verdict of a submission left pending in the
+// journal, if any
+func resolveSubmission(cmd *cobra.Command, sr *api.SubmissionResult) {
+	entries, err := journal.Load()
+	if err != nil {
+		return
+	}
+	for _, e := range entries {
+		if e.Kind == journal.KindSubmission && e.SubmissionID == sr.SubmissionID && e.Verdict == api.VerdictUnknown {
+			recordSubmission(cmd, e, sr)
+			return
+		}
+	}
+}
+
+// recordInterpretation journals the verdict of an interpretation of the
+// solution file fp, with the code that was judged rather than what fp holds
+// by now
+func recordInterpretation(cmd *cobra.Command, pd *model.ProblemDetail, fp string, ir *api.InterpretResult) {
+	id, _ := strconv.Atoi(pd.QuestionFrontendID)
+	appendJournal(cmd, journal.Entry{
+		Time:         time.Now().UTC(),
+		Kind:         journal.KindInterpretation,
+		ProblemID:    id,
+		Lang:         ir.Lang,
+		File:         absPath(fp),
+		CodeHash:     journal.HashCode(ir.TypedCode),
+		SubmissionID: ir.SubmissionID,
+		Verdict:      ir.Verdict(),
+		Runtime:      ir.StatusRuntime,
+		Memory:       ir.StatusMemory,
+	})
+}
+
+func appendJournal(cmd *cobra.Command, e journal.Entry) {
+	err := journal.Append(e)
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: cannot record %s %s: %v\n", e.Kind, e.SubmissionID, err)
+	}
+}
+
+// absPath returns fp made absolute, or fp itself when that fails
+func absPath(fp string) string {
+	abs, err := filepath.Abs(fp)
+	if err != nil {
+		return fp
+	}
+	return abs
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// resultSchemaVersion is bumped on every incompatible change of resultDocument
+const resultSchemaVersion = 1
+
+// resultDocument is the stable json representation of a judge result
+type resultDocument struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	Kind          string           `json:"kind"`
+	ID            string           `json:"id"`
+	Verdict       api.Verdict      `json:"verdict"`
+	Status        resultStatus     `json:"status"`
+	Lang          string           `json:"lang"`
+	Runtime       resultMeasure    `json:"runtime"`
+	Memory        resultMeasure    `json:"memory"`
+	TestCases     *resultTestCases `json:"testCases,omitempty"`
+	Cases         []resultCase     `json:"cases,omitempty"`
+	Input         string           `json:"input"`
+	Expected      []string         `json:"expected"`
+	Actual        []string         `json:"actual"`
+	Stdout        string           `json:"stdout"`
+	CompileError  string           `json:"compileError"`
+	RuntimeError  string           `json:"runtimeError"`
+}
+
+type resultStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type resultMeasure struct {
+	Display    string   `json:"display"`
+	Percentile *float32 `json:"percentile"`
+}
+
+type resultCase struct {
+	Input    string `json:"input"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Passed   bool   `json:"passed"`
+	Stdout   string `json:"stdout"`
+	Stored   string `json:"stored,omitempty"`
+}
+
+type resultTestCases struct {
+	Passed int `json:"passed"`
+	Total  int `json:"total"`
+}
+
+func submissionDocument(sr *api.SubmissionResult) resultDocument {
+	doc := resultDocument{
+		SchemaVersion: resultSchemaVersion,
+		Kind:          "submission",
+		ID:            sr.SubmissionID,
+		Verdict:       sr.Verdict(),
+		Status:        resultStatus{Code: sr.StatusCode, Message: sr.StatusMsg},
+		Lang:          sr.Lang,
+		Runtime:       resultMeasure{Display: sr.StatusRuntime},
+		Memory:        resultMeasure{Display: sr.StatusMemory},
+		TestCases:     &resultTestCases{Passed: sr.TotalCorrect, Total: sr.TotalTestcases},
+		Input:         sr.LastTestcase,
+		Expected:      []string{},
+		Actual:        []string{},
+		Stdout:        sr.StdOutput,
+		CompileError:  utils.FirstNonEmpty(sr.FullCompileError, sr.CompileError),
+		RuntimeError:  utils.FirstNonEmpty(sr.FullRuntimeError, sr.RuntimeError),
+	}
+
+	if doc.Verdict == api.VerdictAccepted {
+		doc.Runtime.Percentile = &sr.RuntimePercentile
+		doc.Memory.Percentile = &sr.MemoryPercentile
+	}
+	if sr.ExpectedOutput != "" || sr.CodeOutput != "" {
+		doc.Expected = []string{sr.ExpectedOutput}
+		doc.Actual = []string{sr.CodeOutput}
+	}
+	return doc
+}
+
+func interpretationDocument(ir *api.InterpretResult) resultDocument {
+	doc := resultDocument{
+		SchemaVersion: resultSchemaVersion,
+		Kind:          "interpretation",
+		ID:            ir.SubmissionID,
+		Verdict:       ir.Verdict(),
+		Status:        resultStatus{Code: ir.StatusCode, Message: ir.StatusMsg},
+		Lang:          ir.Lang,
+		Runtime:       resultMeasure{Display: ir.StatusRuntime},
+		Memory:        resultMeasure{Display: ir.StatusMemory},
+		TestCases:     &resultTestCases{Passed: ir.TotalCorrect, Total: ir.TotalTestcases},
+		Input:         ir.DataInput,
+		Expected:      ir.ExpectedCodeAnswer,
+		Actual:        ir.CodeAnswer,
+		Stdout:        strings.Join(ir.CodeOutput, "\n"),
+		CompileError:  utils.FirstNonEmpty(ir.FullCompileError, ir.CompileError),
+		RuntimeError:  utils.FirstNonEmpty(ir.FullRuntimeError, ir.RuntimeError),
+	}
+
+	if doc.Verdict == api.VerdictAccepted {
+		doc.Runtime.Percentile = percentile(ir.RuntimePercentile)
+		doc.Memory.Percentile = percentile(ir.MemoryPercentile)
+	}
+
+	stdout := ir.Stdout()
+	for i, cr := range ir.CaseResults() {
+		rc := resultCase{
+			Input:    cr.Input,
+			Expected: cr.Expected,
+			Actual:   cr.Actual,
+			Passed:   cr.Passed,
+			Stored:   cr.Stored,
+		}
+		if len(stdout) > 1 && i < len(stdout) {
+			rc.Stdout = stdout[i]
+		}
+		doc.Cases = append(doc.Cases, rc)
+	}
+	if doc.Expected == nil {
+		doc.Expected = []string{}
+	}
+	if doc.Actual == nil {
+		doc.Actual = []string{}
+	}
+	return doc
+}
+
+// percentile parses a percentile the judge reports as a string, nil when it
+// reports none
+func percentile(s string) *float32 {
+	p, err := strconv.ParseFloat(s, 32)
+	if err != nil {
+		return nil
+	}
+	f := float32(p)
+	return &f
+}
+
+// outputSubmission writes sr of the source file fp, empty when unknown, in
+// the format selected by the `output` flag
+func outputSubmission(cmd *cobra.Command, sr *api.SubmissionResult, fp string) error {
+	if output, _ := cmd.Flags().GetString("output"); output == "json" {
+		return writeJSON(cmd, submissionDocument(sr))
+	}
+	opts := renderOptionsFromFlags(cmd)
+	opts.LineOffset = sourceLineOffset(fp)
+	renderSubmission(cmd.OutOrStdout(), sr, opts)
+	return nil
+}
+
+// outputInterpretation writes ir of the source file fp, empty when unknown,
+// in the format selected by the `output` flag
+func outputInterpretation(cmd *cobra.Command, ir *api.InterpretResult, fp string) error {
+	if output, _ := cmd.Flags().GetString("output"); output == "json" {
+		return writeJSON(cmd, interpretationDocument(ir))
+	}
+	opts := renderOptionsFromFlags(cmd)
+	opts.LineOffset = sourceLineOffset(fp)
+	renderInterpretation(cmd.OutOrStdout(), ir, opts)
+	return nil
+}
+
+func writeJSON(cmd *cobra.Command, v interface{}) error {
+	enc := json.NewEncoder(cmd.OutOrStdout())
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+var update = flag.Bool("update", false, "update the golden files of testdata")
+
+func TestInterpretationDocument(t *testing.T) {
+	ir := &api.InterpretResult{
+		DataInput:          "[2,7,11,15]\n9\n[3,3]\n6",
+		TestCases:          []string{"[2,7,11,15]\n9", "[3,3]\n6"},
+		StoredExpected:     []string{"", "[0,1]"},
+		SubmissionID:       "runcode_1_2",
+		StatusCode:         10,
+		StatusMsg:          "Accepted",
+		Lang:               "golang",
+		RunSuccess:         true,
+		CorrectAnswer:      true,
+		StatusRuntime:      "0 ms",
+		StatusMemory:       "4.2 MB",
+		RuntimePercentile:  "92.5",
+		MemoryPercentile:   "61.2",
+		TotalCorrect:       2,
+		TotalTestcases:     2,
+		CodeAnswer:         []string{"[0,1]", "[0,1]"},
+		ExpectedCodeAnswer: []string{"[0,1]", "[0,1]"},
+		CodeOutput:         []string{"first", "second"},
+		StdOutputList:      []string{"first\n", "second\n", ""},
+	}
+
+	var b bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&b)
+	if err := writeJSON(cmd, interpretationDocument(ir)); err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "interpretation.json")
+	if *update {
+		if err := os.WriteFile(golden, b.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("interpretation document differs from %s:\n%s", golden, b.String())
+	}
+}
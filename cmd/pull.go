@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/bulk"
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	RootCmd.AddCommand(pullCmd)
+	pullCmd.Flags().IntP("id", "i", 0, "ID of problem whose accepted solution is pulled")
+	pullCmd.Flags().Bool("all", false, "pull the accepted solution of every solved problem")
+	pullCmd.Flags().StringP("lang", "l", "", "only pull solutions in a language, as slug, name or file extension")
+	pullCmd.Flags().Bool("merge", false, "replace the @lc code region of existing source files holding other code")
+	pullCmd.Flags().Int("workers", bulk.DefaultWorkers, "number of problems pulled concurrently")
+	pullCmd.Flags().Float64("rate", defaultRequestRate, "maximum requests per second to leetcode, 0 disables the limit")
+	pullCmd.Flags().Bool("restart", false, "pull every problem again instead of resuming an interrupted --all")
+}
+
+var pullCmd = &cobra.Command{
+	Use:   `pull`,
+	Short: `Pull accepted solutions into the workspace`,
+	Long: `Download the latest accepted submission of solved problems to the
+source file given by the sourceCodePath template, generating the markdown of
+problems that have none.
+
+Existing source files holding other code are skipped unless --merge replaces
+their '@lc code=start/end' region. An interrupted or partially failed --all
+resumes with the remaining problems when run again.`,
+	Args: arg.Pull,
+	RunE: pull,
+}
+
+func pull(cmd *cobra.Command, args []string) error {
+	all, _ := cmd.Flags().GetBool("all")
+	lang, _ := cmd.Flags().GetString("lang")
+	restart, _ := cmd.Flags().GetBool("restart")
+
+	client, err := bulkClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	slugs, err := bulkProblems(cmd, client, "approved")
+	if err != nil {
+		return err
+	}
+
+	var jobs []bulk.Job
+	for _, slug := range slugs {
+		slug := slug
+		jobs = append(jobs, bulk.Job{
+			Key: slug,
+			Run: func(ctx context.Context) (string, error) {
+				return pullSolution(ctx, cmd, client, slug)
+			},
+		})
+	}
+
+	opts := bulkOptions(cmd)
+	if all {
+		opts.StatePath = filepath.Join(utils.BulkStateDir, strings.TrimSuffix("pull-all-"+lang, "-"))
+		if restart {
+			os.Remove(opts.StatePath)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	report, err := bulk.Run(ctx, jobs, opts)
+	return bulkSummary(cmd, "pulled", report, err)
+}
+
+// pullSolution exports the latest accepted submission to problem slug in the
+// language of the `lang` flag, until ctx is done
+func pullSolution(ctx context.Context, cmd *cobra.Command, client *api.Client, slug string) (string, error) {
+	lang, _ := cmd.Flags().GetString("lang")
+	merge, _ := cmd.Flags().GetBool("merge")
+
+	accepted, err := client.FindSubmission(ctx, slug, func(s api.SubmissionSummary) bool {
+		if s.Verdict() != api.VerdictAccepted {
+			return false
+		}
+		l, err := model.GetLanguage(s.Lang)
+		if err != nil {
+			l = model.Language{Slug: s.Lang}
+		}
+		return lang == "" || l.Matches(lang)
+	})
+	if err != nil {
+		return "", err
+	}
+	if accepted == nil {
+		return fmt.Sprintf("%s %s, no accepted submission", utils.Gray(pullLabel("none")), slug), nil
+	}
+
+	problemDetail, err := client.GetProblemDetailBySlug(ctx, slug)
+	if err != nil {
+		return "", err
+	}
+	detail, err := client.GetSubmissionDetail(ctx, accepted.ID)
+	if err != nil {
+		return "", err
+	}
+
+	fp, outcome, err := problemDetail.ExportSolution(model.Solution{
+		SubmissionID: accepted.ID,
+		Lang:         accepted.Lang,
+		Code:         detail.Code,
+	}, merge)
+	if err != nil {
+		return "", err
+	}
+
+	if outcome == model.ExportSkipped {
+		return fmt.Sprintf("%s %s, local code differs from submission %s, use --merge to replace it", utils.Yellow(pullLabel(outcome)), fp, accepted.ID), nil
+	}
+	return fmt.Sprintf("%s %s, submission %s", utils.Green(pullLabel(outcome)), fp, accepted.ID), nil
+}
+
+// pullLabel pads the outcome of a pull so that paths line up
+func pullLabel(outcome string) string {
+	return fmt.Sprintf("%-10s", outcome)
+}
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/diff"
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/kyokomi/emoji"
+	"github.com/spf13/cobra"
+)
+
+// defaultStdoutLimit is the number of stdout lines shown per test case
+const defaultStdoutLimit = 50
+
+// renderOptions tunes how judge results are printed
+type renderOptions struct {
+	// StdoutOnly prints nothing but what the code printed
+	StdoutOnly bool
+	// StdoutLimit truncates the stdout of each test case, 0 disables it
+	StdoutLimit int
+	// LineOffset is added to the error lines reported by the judge, which
+	// count from the start of the `@lc code` region
+	LineOffset int
+}
+
+// renderOptionsFromFlags reads renderOptions from the flags of cmd
+func renderOptionsFromFlags(cmd *cobra.Command) renderOptions {
+	stdoutOnly, _ := cmd.Flags().GetBool("stdout-only")
+	stdoutLimit, err := cmd.Flags().GetInt("stdout-limit")
+	if err != nil {
+		stdoutLimit = defaultStdoutLimit
+	}
+	return renderOptions{StdoutOnly: stdoutOnly, StdoutLimit: stdoutLimit}
+}
+
+// sourceLineOffset returns the number of lines of the source file fp before
+// its `@lc code` region, 0 when fp is unknown or cannot be read
+func sourceLineOffset(fp string) int {
+	if fp == "" {
+		return 0
+	}
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		return 0
+	}
+	return model.CodeOffset(string(b))
+}
+
+// renderSubmission prints the judge verdict of a submission
+func renderSubmission(w io.Writer, sr *api.SubmissionResult, opts renderOptions) {
+	if opts.StdoutOnly {
+		renderStdoutOnly(w, []string{sr.StdOutput}, opts)
+		return
+	}
+
+	v := sr.Verdict()
+	renderVerdict(w, v, sr.StatusMsg)
+
+	switch v {
+	case api.VerdictAccepted:
+		fmt.Fprintf(w, "%d/%d test cases passed\n\n", sr.TotalCorrect, sr.TotalTestcases)
+		fmt.Fprintf(w, "%s\n", utils.Blue("Runtime"))
+		fmt.Fprintf(w, "%s, faster than %.2f%% submissions\n\n", sr.StatusRuntime, sr.RuntimePercentile)
+		fmt.Fprintf(w, "%s\n", utils.Blue("Memory"))
+		fmt.Fprintf(w, "%s, less than %.2f%% submissions\n", sr.StatusMemory, sr.MemoryPercentile)
+		return
+	case api.VerdictCompileError:
+		renderCompileError(w, sr.CompileError, sr.FullCompileError, opts.LineOffset)
+		return
+	}
+
+	fmt.Fprintf(w, "%d/%d test cases passed\n\n", sr.TotalCorrect, sr.TotalTestcases)
+	renderTestCase(w, "Last Test Case", sr.LastTestcase)
+
+	switch v {
+	case api.VerdictRuntimeError:
+		renderRuntimeError(w, sr.RuntimeError, sr.FullRuntimeError, opts.LineOffset)
+	case api.VerdictTimeLimitExceeded, api.VerdictMemoryLimitExceeded, api.VerdictOutputLimitExceeded:
+		renderLimitExceeded(w, v)
+	default:
+		fmt.Fprintf(w, "%s\n", utils.Red(v.String()))
+		renderAnswerDiff(w, "Expected   ", "Actual     ", sr.ExpectedOutput, sr.CodeOutput)
+	}
+
+	renderStdout(w, []string{sr.StdOutput}, opts)
+}
+
+// renderInterpretation prints the judge result of an interpretation
+func renderInterpretation(w io.Writer, ir *api.InterpretResult, opts renderOptions) {
+	if opts.StdoutOnly {
+		renderStdoutOnly(w, ir.Stdout(), opts)
+		return
+	}
+
+	v := ir.Verdict()
+	renderVerdict(w, v, ir.StatusMsg)
+
+	if v == api.VerdictCompileError {
+		renderCompileError(w, ir.CompileError, ir.FullCompileError, opts.LineOffset)
+		return
+	}
+
+	// a run that did not complete is explained by the input it failed on,
+	// the others by the per-case table
+	multiple := len(ir.TestCases) > 1
+	completed := v == api.VerdictAccepted || v == api.VerdictWrongAnswer
+	switch {
+	case !multiple:
+		renderTestCase(w, "Test Case", ir.DataInput)
+	case !completed && ir.LastTestcase != "":
+		renderTestCase(w, "Last Test Case", ir.LastTestcase)
+	case !completed:
+		renderTestCase(w, "Test Cases", ir.DataInput)
+	}
+
+	switch v {
+	case api.VerdictRuntimeError:
+		renderRuntimeError(w, ir.RuntimeError, ir.FullRuntimeError, opts.LineOffset)
+	case api.VerdictTimeLimitExceeded, api.VerdictMemoryLimitExceeded, api.VerdictOutputLimitExceeded:
+		renderLimitExceeded(w, v)
+	default:
+		fmt.Fprintf(w, "%s\n", utils.Blue("Answer"))
+		if multiple {
+			renderCaseTable(w, ir.CaseResults())
+		} else {
+			renderAnswerDiff(
+				w,
+				"Expected: ",
+				"Actual:   ",
+				strings.Join(ir.ExpectedCodeAnswer, ", "),
+				strings.Join(ir.CodeAnswer, ", "),
+			)
+		}
+		renderStoredMismatches(w, ir.CaseResults())
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s\n", utils.Blue("Runtime"))
+		fmt.Fprintf(w, "Expected: %s ms\n", ir.ExpectedStatusRuntime)
+		fmt.Fprintf(w, "Actual:   %s\n\n", ir.StatusRuntime)
+		fmt.Fprintf(w, "%s\n", utils.Blue("Memory"))
+		fmt.Fprintf(w, "Expected: %.2f MB\n", float32(ir.ExpectedMemory)/float32(1024)/float32(1024))
+		fmt.Fprintf(w, "Actual:   %s\n", ir.StatusMemory)
+	}
+
+	renderStdout(w, ir.Stdout(), opts)
+}
+
+func renderVerdict(w io.Writer, v api.Verdict, statusMsg string) {
+	if v == api.VerdictAccepted {
+		emoji.Fprintf(w, "%s :heavy_check_mark:\n\n", utils.Green(v.String()))
+		return
+	}
+
+	title := v.String()
+	if v == api.VerdictUnknown && statusMsg != "" {
+		title = statusMsg
+	}
+	emoji.Fprintf(w, "%s :x:\n\n", utils.Red(title))
+}
+
+func renderTestCase(w io.Writer, title string, input string) {
+	if input == "" {
+		return
+	}
+	fmt.Fprintf(w, "%s\n%s\n\n", utils.Cyan(title), strings.ReplaceAll(input, "\n", "\\n"))
+}
+
+// caseColumnWidth truncates inputs and answers in the per-case table
+const caseColumnWidth = 40
+
+func renderCaseTable(w io.Writer, results []api.CaseResult) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	// result comes last as its color codes would skew the column widths
+	fmt.Fprintln(tw, "#\tInput\tExpected\tActual\tResult")
+	passed := 0
+	for i, cr := range results {
+		result := utils.Red("FAIL")
+		if cr.Passed {
+			result = utils.Green("PASS")
+			passed++
+		}
+		fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s\t%s\n",
+			i+1,
+			truncate(strings.ReplaceAll(cr.Input, "\n", "\\n"), caseColumnWidth),
+			truncate(cr.Expected, caseColumnWidth),
+			truncate(cr.Actual, caseColumnWidth),
+			result,
+		)
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "%d/%d test cases passed\n", passed, len(results))
+
+	for i, cr := range results {
+		if !cr.Passed && cr.Actual != "" && cr.Actual != cr.Expected {
+			fmt.Fprintf(w, "\n%s\n", utils.Cyan(fmt.Sprintf("Case %d", i+1)))
+			renderAnswerDiff(w, "Expected: ", "Actual:   ", cr.Expected, cr.Actual)
+			break
+		}
+	}
+}
+
+// renderStoredMismatches prints the answers differing from the expected
+// answer stored in the test case library
+func renderStoredMismatches(w io.Writer, results []api.CaseResult) {
+	for i, cr := range results {
+		if cr.Stored == "" || cr.Actual == "" || diff.Compare(cr.Stored, cr.Actual).Equal {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s\n", utils.Yellow(fmt.Sprintf("Case %d differs from its stored expected answer", i+1)))
+		renderAnswerDiff(w, "Stored:   ", "Actual:   ", cr.Stored, cr.Actual)
+	}
+}
+
+// renderAnswerDiff prints expected and actual answers with their first
+// difference highlighted, or a line diff when they are not json-like
+func renderAnswerDiff(w io.Writer, expectedLabel string, actualLabel string, expected string, actual string) {
+	d := diff.Compare(expected, actual)
+	if d.Equal {
+		fmt.Fprintf(w, "%s%s\n", expectedLabel, expected)
+		fmt.Fprintf(w, "%s%s\n", actualLabel, actual)
+		return
+	}
+
+	if d.Structural {
+		e, a := d.Highlight(utils.Red)
+		fmt.Fprintf(w, "%s%s\n", expectedLabel, e)
+		fmt.Fprintf(w, "%s%s\n", actualLabel, a)
+		fmt.Fprintf(w, "%s%s\n", strings.Repeat(" ", len(actualLabel)), utils.Yellow(d.Summary()))
+		return
+	}
+
+	if !strings.Contains(expected, "\n") && !strings.Contains(actual, "\n") {
+		fmt.Fprintf(w, "%s%s\n", expectedLabel, expected)
+		fmt.Fprintf(w, "%s%s\n", actualLabel, actual)
+		return
+	}
+
+	fmt.Fprintf(w, "%s\n", utils.Gray("--- expected\n+++ actual"))
+	for _, line := range d.Lines {
+		switch line.Op {
+		case '-':
+			fmt.Fprintln(w, utils.Red("-"+line.Text))
+		case '+':
+			fmt.Fprintln(w, utils.Green("+"+line.Text))
+		default:
+			fmt.Fprintln(w, " "+line.Text)
+		}
+	}
+}
+
+func truncate(s string, width int) string {
+	r := []rune(s)
+	if len(r) <= width {
+		return s
+	}
+	return string(r[:width-3]) + "..."
+}
+
+func renderCompileError(w io.Writer, compileError string, fullCompileError string, lineOffset int) {
+	message := utils.FirstNonEmpty(fullCompileError, compileError)
+
+	fmt.Fprintf(w, "%s", utils.Red("Compile Error"))
+	if line := api.ErrorLine(message); line > 0 {
+		fmt.Fprintf(w, " at line %d", line+lineOffset)
+	}
+	fmt.Fprintf(w, "\n%s\n", utils.Magenta(message))
+}
+
+func renderRuntimeError(w io.Writer, runtimeError string, fullRuntimeError string, lineOffset int) {
+	message := utils.FirstNonEmpty(fullRuntimeError, runtimeError)
+
+	fmt.Fprintf(w, "%s", utils.Red("Runtime Error"))
+	if line := api.ErrorLine(message); line > 0 {
+		fmt.Fprintf(w, " at line %d", line+lineOffset)
+	}
+	fmt.Fprintf(w, "\n%s\n", utils.Magenta(message))
+}
+
+func renderLimitExceeded(w io.Writer, v api.Verdict) {
+	var reason string
+	switch v {
+	case api.VerdictTimeLimitExceeded:
+		reason = "execution did not finish in time on the test case above"
+	case api.VerdictMemoryLimitExceeded:
+		reason = "memory usage exceeded the limit on the test case above"
+	case api.VerdictOutputLimitExceeded:
+		reason = "too much output was printed on the test case above"
+	}
+	fmt.Fprintf(w, "%s\n%s\n", utils.Red(v.String()), reason)
+}
+
+// renderStdout prints what the code printed, split by test case when the
+// judge reported several outputs
+func renderStdout(w io.Writer, outputs []string, opts renderOptions) {
+	if isBlank(outputs) {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s\n", utils.Blue("Stdout"))
+	renderStdoutCases(w, outputs, opts)
+}
+
+// renderStdoutOnly prints nothing but what the code printed
+func renderStdoutOnly(w io.Writer, outputs []string, opts renderOptions) {
+	if isBlank(outputs) {
+		fmt.Fprintln(w, utils.Gray("(no output)"))
+		return
+	}
+	renderStdoutCases(w, outputs, opts)
+}
+
+func renderStdoutCases(w io.Writer, outputs []string, opts renderOptions) {
+	for i, output := range outputs {
+		if len(outputs) > 1 {
+			fmt.Fprintf(w, "%s\n", utils.Cyan(fmt.Sprintf("Case %d", i+1)))
+		}
+		if strings.TrimSpace(output) == "" {
+			fmt.Fprintln(w, utils.Gray("(no output)"))
+			continue
+		}
+
+		lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+		if opts.StdoutLimit <= 0 || len(lines) <= opts.StdoutLimit {
+			fmt.Fprintln(w, strings.Join(lines, "\n"))
+			continue
+		}
+		fmt.Fprintln(w, strings.Join(lines[:opts.StdoutLimit], "\n"))
+		fmt.Fprintln(w, utils.Yellow(fmt.Sprintf(
+			"... %d more line(s) truncated at %d lines, raise the limit with --stdout-limit",
+			len(lines)-opts.StdoutLimit,
+			opts.StdoutLimit,
+		)))
+	}
+}
+
+func isBlank(outputs []string) bool {
+	for _, output := range outputs {
+		if strings.TrimSpace(output) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -1,18 +1,34 @@
 package cmd
 
 import (
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	RootCmd.PersistentFlags().Bool("help", false, "Show help for command")
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return &arg.FlagError{Err: err}
+	})
 }
 
 // RootCmd is the entry point of command-line execution
 var RootCmd = &cobra.Command{
-	Use:           "lc <command> <subcommand> [flags]",
-	Short:         "leetcode CLI",
-	Long:          `Work seamlessly with leetcode from the command line.`,
+	Use:   "lc <command> <subcommand> [flags]",
+	Short: "leetcode CLI",
+	Long: `Work seamlessly with leetcode from the command line.
+
+Exit codes:
+  0  success, solution accepted
+  1  any other failure
+  2  invalid command, flags or arguments
+  3  wrong answer
+  4  compile error
+  5  runtime error
+  6  time, memory or output limit exceeded
+  7  missing or expired leetcode session
+  8  leetcode could not be reached
+  9  judge did not finish before timeout or interrupt`,
 	SilenceErrors: true,
 	SilenceUsage:  true,
 }
@@ -10,7 +10,7 @@ func init() {
 	RootCmd.AddCommand(showCmd)
 	showCmd.Flags().IntP("id", "i", 0, "ID of problem to be shown")
 	showCmd.Flags().BoolP("random", "r", false, "Random choice of problem to be shown")
-	showCmd.Flags().StringP("language", "l", "", "Open source code in editor")
+	showCmd.Flags().StringP("language", "l", "", "language of the source code to export, as slug, name or file extension")
 }
 
 var showCmd = &cobra.Command{
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+// resolveSource returns the absolute path of the solution file given by the
+// `file` flag or the first argument, and the problem ID given by the `id`
+// flag or inferred from the file
+func resolveSource(cmd *cobra.Command, args []string) (string, int, error) {
+	id, _ := cmd.Flags().GetInt("id")
+	file, _ := cmd.Flags().GetString("file")
+	if file == "" && len(args) > 0 {
+		file = args[0]
+	}
+
+	fp, err := filepath.Abs(file)
+	if err != nil {
+		return "", 0, err
+	}
+	if id != 0 {
+		return fp, id, nil
+	}
+
+	sh, err := model.ResolveSource(fp)
+	if err != nil {
+		return "", 0, err
+	}
+	if sh.ID == 0 {
+		return "", 0, fmt.Errorf(
+			"cannot infer the problem of %s, add an `@lc id=N` header or provide 'id'",
+			file,
+		)
+	}
+	return fp, sh.ID, nil
+}
+
+// printSourceCode prints the code of the solution file fp as sent to the judge
+func printSourceCode(cmd *cobra.Command, pd *model.ProblemDetail, fp string) error {
+	lang, err := pd.GetSourceLanguage(fp)
+	if err != nil {
+		return err
+	}
+
+	code, err := model.ReadSourceCode(fp, lang)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), strings.TrimRight(code, "\n"))
+	return nil
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// distributionWidth is the length of the longest runtime distribution bar
+const distributionWidth = 40
+
+func init() {
+	RootCmd.AddCommand(submissionsCmd)
+	submissionsCmd.Flags().IntP("id", "i", 0, "ID of problem whose submissions are listed")
+	submissionsCmd.Flags().IntP("limit", "n", 20, "list only the latest submissions, 0 lists all of them")
+	submissionsCmd.Flags().StringP("output", "o", "text", "output format: {text|json}")
+
+	RootCmd.AddCommand(submissionCmd)
+	submissionCmd.Flags().StringP("output", "o", "text", "output format: {text|json}")
+}
+
+var submissionsCmd = &cobra.Command{
+	Use:   `submissions`,
+	Short: `List past submissions of a problem`,
+	Long: `List the submissions made to a problem on leetcode, latest first, with
+their verdict, language, runtime and memory`,
+	Args: arg.Submissions,
+	RunE: submissions,
+}
+
+var submissionCmd = &cobra.Command{
+	Use:   `submission <submission-id>`,
+	Short: `Show a past submission`,
+	Long: `Show the verdict of a past submission along with its failing test case,
+runtime distribution and source code`,
+	Args: arg.Submission,
+	RunE: submission,
+}
+
+// submissionListItem is the json representation of a listed submission
+type submissionListItem struct {
+	ID      string      `json:"id"`
+	Time    time.Time   `json:"time"`
+	Verdict api.Verdict `json:"verdict"`
+	Lang    string      `json:"lang"`
+	Runtime string      `json:"runtime"`
+	Memory  string      `json:"memory"`
+}
+
+// submissionDetailDocument is the json representation of a past submission,
+// the judge result document extended with the submitted code
+type submissionDetailDocument struct {
+	resultDocument
+	ProblemID           string                   `json:"problemId"`
+	TitleSlug           string                   `json:"titleSlug"`
+	Time                time.Time                `json:"time"`
+	Code                string                   `json:"code"`
+	RuntimeDistribution []api.DistributionBucket `json:"runtimeDistribution"`
+}
+
+func submissions(cmd *cobra.Command, args []string) error {
+	id, _ := cmd.Flags().GetInt("id")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	client, err := api.GetAuthClient()
+	if err != nil {
+		return err
+	}
+
+	problemDetail, err := client.GetProblemDetail(id, false)
+	if err != nil {
+		return err
+	}
+
+	list, err := client.GetSubmissions(context.Background(), problemDetail.TitleSlug, limit)
+	if err != nil {
+		return err
+	}
+
+	if output, _ := cmd.Flags().GetString("output"); output == "json" {
+		items := make([]submissionListItem, 0, len(list))
+		for _, s := range list {
+			items = append(items, submissionListItem{
+				ID:      s.ID,
+				Time:    s.Time().UTC(),
+				Verdict: s.Verdict(),
+				Lang:    s.Lang,
+				Runtime: s.Runtime,
+				Memory:  s.Memory,
+			})
+		}
+		return writeJSON(cmd, items)
+	}
+
+	if len(list) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "no submission to problem %d\n", id)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTIME\tVERDICT\tLANG\tRUNTIME\tMEMORY")
+	for _, s := range list {
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.ID,
+			s.Time().Local().Format("2006-01-02 15:04"),
+			colorVerdict(s.Verdict()),
+			s.Lang,
+			s.Runtime,
+			s.Memory,
+		)
+	}
+	return w.Flush()
+}
+
+func submission(cmd *cobra.Command, args []string) error {
+	client, err := api.GetAuthClient()
+	if err != nil {
+		return err
+	}
+
+	detail, err := client.GetSubmissionDetail(context.Background(), args[0])
+	if err != nil {
+		return err
+	}
+
+	buckets, err := detail.RuntimeBuckets()
+	if err != nil {
+		return err
+	}
+
+	result := detail.Result()
+	if output, _ := cmd.Flags().GetString("output"); output == "json" {
+		if buckets == nil {
+			buckets = []api.DistributionBucket{}
+		}
+		return writeJSON(cmd, submissionDetailDocument{
+			resultDocument:      submissionDocument(result),
+			ProblemID:           detail.Question.QuestionFrontendID,
+			TitleSlug:           detail.Question.TitleSlug,
+			Time:                detail.Time().UTC(),
+			Code:                detail.Code,
+			RuntimeDistribution: buckets,
+		})
+	}
+
+	w := cmd.OutOrStdout()
+	fmt.Fprintf(
+		w,
+		"%s\n\n",
+		utils.Gray(fmt.Sprintf(
+			"submission %s to %s. %s in %s on %s",
+			detail.ID,
+			detail.Question.QuestionFrontendID,
+			detail.Question.TitleSlug,
+			detail.Lang.VerboseName,
+			detail.Time().Local().Format("2006-01-02 15:04"),
+		)),
+	)
+	renderSubmission(w, result, renderOptions{StdoutLimit: defaultStdoutLimit})
+
+	if len(buckets) > 0 {
+		fmt.Fprintf(w, "\n%s\n", utils.Blue("Runtime Distribution"))
+		renderDistribution(w, buckets, detail.Runtime)
+	}
+
+	fmt.Fprintf(w, "\n%s\n", utils.Blue("Code"))
+	fmt.Fprintln(w, strings.TrimRight(detail.Code, "\n"))
+	return nil
+}
+
+// renderDistribution draws buckets as bars, highlighting the one holding
+// runtime
+func renderDistribution(w io.Writer, buckets []api.DistributionBucket, runtime int) {
+	var max float64
+	for _, b := range buckets {
+		if b.Percent > max {
+			max = b.Percent
+		}
+	}
+
+	// the last bucket not above runtime holds it
+	own := -1
+	for i, b := range buckets {
+		if value, err := strconv.Atoi(b.Value); err == nil && value <= runtime {
+			own = i
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 1, ' ', tabwriter.AlignRight)
+	for i, b := range buckets {
+		// an empty or all-zero distribution has no bars to scale
+		var bar string
+		if max > 0 && b.Percent > 0 {
+			bar = strings.Repeat("█", int(b.Percent/max*distributionWidth+0.5))
+		}
+		line := fmt.Sprintf("%s ms\t%.1f%%\t", b.Value, b.Percent)
+		if i == own {
+			fmt.Fprintf(tw, "%s %s %s\n", line, utils.Green(bar), utils.Green("← you"))
+			continue
+		}
+		fmt.Fprintf(tw, "%s %s\n", line, bar)
+	}
+	tw.Flush()
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+)
+
+func TestRenderDistribution(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		buckets []api.DistributionBucket
+		bars    int
+	}{
+		{"empty", nil, 0},
+		{"all zero", []api.DistributionBucket{{Value: "0", Percent: 0}, {Value: "4", Percent: 0}}, 0},
+		{"scaled", []api.DistributionBucket{{Value: "0", Percent: 10}, {Value: "4", Percent: 40}}, distributionWidth/4 + distributionWidth},
+	} {
+		var b bytes.Buffer
+		renderDistribution(&b, tt.buckets, 4)
+		if bars := strings.Count(b.String(), "█"); bars != tt.bars {
+			t.Errorf("%s: %d bar blocks, want %d:\n%s", tt.name, bars, tt.bars, b.String())
+		}
+	}
+}
@@ -1,32 +1,48 @@
 package cmd
 
 import (
-	"path/filepath"
+	"context"
+	"fmt"
+	"strings"
 
 	"github.com/ckidckidckid/leetcode-cli/pkg/api"
 	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/journal"
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/ckidckidckid/leetcode-cli/pkg/testcase"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	RootCmd.AddCommand(submitCmd)
-	submitCmd.PersistentFlags().IntP("id", "i", 0, "ID of problem to be submitted")
+	submitCmd.PersistentFlags().IntP("id", "i", 0, "ID of problem to be submitted, inferred from the file by default")
 	submitCmd.PersistentFlags().StringP("file", "f", "", "path of file to be submitted")
+	submitCmd.PersistentFlags().StringP("output", "o", "text", "output format: {text|json}")
+	submitCmd.PersistentFlags().Bool("save-failing", true, "store the failing test case of a rejected submission in the test case library")
+	submitCmd.PersistentFlags().Bool("stdout-only", false, "print nothing but the stdout of the solution")
+	submitCmd.PersistentFlags().Int("stdout-limit", defaultStdoutLimit, "maximum stdout lines shown per test case, 0 shows everything")
+	submitCmd.PersistentFlags().Bool("print-bundle", false, "print the code sent to the judge, with local Go packages inlined, instead of sending it")
+	submitCmd.PersistentFlags().Bool("verify", false, "interpret the examples and stored test cases first, submitting only if all of them pass")
+	submitCmd.PersistentFlags().Bool("force", false, "submit even if identical code was already judged")
+	submitCmd.PersistentFlags().Bool("dry-run", false, "print the submission request, the JSON payload around the code --print-bundle prints, instead of posting it")
+	submitCmd.PersistentFlags().Duration("timeout", defaultJudgeTimeout, "maximum time to wait for the judge result")
 }
 
 var submitCmd = &cobra.Command{
-	Use:   `submit`,
+	Use:   `submit [file]`,
 	Short: `Submit code`,
-	Long:  `Submit local code to leetcode problem`,
-	Args:  arg.Submit,
-	RunE:  submit,
+	Long: `Submit local code to leetcode problem
+
+The problem ID and language are read from the '@lc' header written by
+'lc show', or the problem is matched against the sourceCodePath template when
+the header is missing. The problem ID can be overridden with --id.`,
+	Args: arg.Submit,
+	RunE: submit,
 }
 
 func submit(cmd *cobra.Command, args []string) error {
-	id, _ := cmd.Flags().GetInt("id")
-	file, _ := cmd.Flags().GetString("file")
-
-	fp, err := filepath.Abs(file)
+	fp, id, err := resolveSource(cmd, args)
 	if err != nil {
 		return err
 	}
@@ -41,15 +57,205 @@ func submit(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if printBundle, _ := cmd.Flags().GetBool("print-bundle"); printBundle {
+		return printSourceCode(cmd, problemDetail, fp)
+	}
+
+	payload, err := api.NewSubmitPayload(problemDetail, fp)
+	if err != nil {
+		return err
+	}
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		fmt.Fprintf(cmd.ErrOrStderr(), "POST %s\n", strings.Replace(utils.SubmitURL, "$slug", problemDetail.TitleSlug, 1))
+		return writeJSON(cmd, payload)
+	}
+
 	sClient, err := api.GetSubmitClient(problemDetail)
 	if err != nil {
 		return err
 	}
 
-	err = sClient.SubmitCode(problemDetail, fp)
+	if force, _ := cmd.Flags().GetBool("force"); !force {
+		err = checkDuplicate(cmd, id, payload.Lang, journal.HashCode(payload.TypedCode))
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := judgeContext(cmd)
+	defer cancel()
+
+	verify, err := shouldVerify(cmd)
+	if err != nil {
+		return err
+	}
+	if verify {
+		err = verifySubmission(ctx, cmd, sClient, problemDetail, fp)
+		if err != nil {
+			return err
+		}
+	}
+
+	submissionID, err := sClient.PostSubmission(ctx, problemDetail, payload)
 	if err != nil {
 		return err
 	}
+	pending := recordPendingSubmission(cmd, id, fp, payload, submissionID)
 
+	result, err := sClient.CheckSubmission(ctx, submissionID)
+	if err != nil {
+		return pendingHint(err)
+	}
+	recordSubmission(cmd, pending, result)
+
+	err = outputSubmission(cmd, result, fp)
+	if err != nil {
+		return err
+	}
+
+	if saveFailing, _ := cmd.Flags().GetBool("save-failing"); saveFailing {
+		warnSaveFailing(cmd, saveFailingCase(cmd, id, result))
+	}
+
+	return verdictError(result.Verdict())
+}
+
+// checkDuplicate refuses, or only warns about with `"duplicateSubmissions":
+// "warn"`, a submission of code already judged for problem id in lang
+func checkDuplicate(cmd *cobra.Command, id int, lang string, hash string) error {
+	message, err := findDuplicate(id, lang, hash)
+	if err != nil || message == "" {
+		return err
+	}
+
+	c, err := model.GetConfig()
+	if err != nil {
+		return err
+	}
+	if c.DuplicateSubmissions == "warn" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: %s\n", message)
+		return nil
+	}
+	return fmt.Errorf("%s\nuse --force to submit it again", message)
+}
+
+// findDuplicate describes the journaled submission of the code hashed as hash
+// to problem id in lang, or returns an empty string when there is none
+func findDuplicate(id int, lang string, hash string) (string, error) {
+	entries, err := journal.Load()
+	if err != nil {
+		return "", err
+	}
+	e := journal.FindSubmission(entries, id, lang, hash)
+	if e == nil {
+		return "", nil
+	}
+
+	verdict := e.Verdict.String()
+	if e.Verdict == api.VerdictUnknown {
+		verdict = "still pending"
+	}
+	return fmt.Sprintf(
+		"identical code was already submitted to problem %d in %s on %s as submission %s: %s",
+		id,
+		lang,
+		e.Time.Local().Format("2006-01-02 15:04"),
+		e.SubmissionID,
+		verdict,
+	), nil
+}
+
+// shouldVerify reports whether the solution is interpreted before being
+// submitted, from the `verify` flag or else the config default
+func shouldVerify(cmd *cobra.Command) (bool, error) {
+	if cmd.Flags().Changed("verify") {
+		return cmd.Flags().GetBool("verify")
+	}
+	c, err := model.GetConfig()
+	if err != nil {
+		return false, err
+	}
+	return c.VerifyBeforeSubmit, nil
+}
+
+// verifySubmission interprets the solution against the examples and stored
+// test cases of the problem, returning an error naming the case that blocks
+// the submission unless all of them pass
+func verifySubmission(ctx context.Context, cmd *cobra.Command, c *api.Client, pd *model.ProblemDetail, fp string) error {
+	testCases, err := interpretInput(cmd, pd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "verifying %d test case(s) before submitting\n", len(testCases))
+	result, err := interpretBatches(ctx, c, pd, fp, testCases)
+	if err != nil {
+		return pendingHint(err)
+	}
+	err = expectStored(pd, result)
+	if err != nil {
+		return err
+	}
+	recordInterpretation(cmd, pd, fp, result)
+
+	v := result.Verdict()
+	if v == api.VerdictAccepted {
+		return nil
+	}
+
+	err = outputInterpretation(cmd, result, fp)
+	if err != nil {
+		return err
+	}
+
+	reason := fmt.Sprintf("%s while verifying", v)
+	for i, cr := range result.CaseResults() {
+		if !cr.Passed {
+			reason = fmt.Sprintf("%s on test case %d: %s", v, result.FirstCase+i+1, strings.ReplaceAll(cr.Input, "\n", "\\n"))
+			if cr.Expected == cr.Actual {
+				reason += ", which differs from its stored expected answer"
+			}
+			break
+		}
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "submission blocked, %s\n", reason)
+	return verdictError(v)
+}
+
+// warnSaveFailing reports a failure to store the failing test case of a
+// submission, which must not hide its verdict
+func warnSaveFailing(cmd *cobra.Command, err error) {
+	if err != nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "warning: cannot store the failing test case: %v\n", err)
+	}
+}
+
+// saveFailingCase stores the last test case of a rejected submission in the
+// test case library of problem id, so that later interpretations cover it
+func saveFailingCase(cmd *cobra.Command, id int, sr *api.SubmissionResult) error {
+	if sr.Verdict() == api.VerdictAccepted || sr.LastTestcase == "" {
+		return nil
+	}
+
+	suite, err := testcase.Load(id)
+	if err != nil {
+		return err
+	}
+
+	c := testcase.Case{
+		Input:    sr.LastTestcase,
+		Expected: sr.ExpectedOutput,
+		Source:   fmt.Sprintf("submission %s, %s", sr.SubmissionID, sr.Verdict().Slug()),
+	}
+	if !suite.Add(c) {
+		return suite.Save()
+	}
+
+	err = suite.Save()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.ErrOrStderr(), "saved failing test case as case %d in %s\n", len(suite.Cases), testcase.Path(id))
 	return nil
 }
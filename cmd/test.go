@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/diff"
+	"github.com/ckidckidckid/leetcode-cli/pkg/testcase"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	RootCmd.AddCommand(testCmd)
+	testCmd.AddCommand(testAddCmd)
+	testCmd.AddCommand(testListCmd)
+	testCmd.AddCommand(testRemoveCmd)
+	testCmd.AddCommand(testRecordCmd)
+
+	testCmd.PersistentFlags().IntP("id", "i", 0, "ID of problem owning the test cases")
+	testAddCmd.Flags().StringP("test_input", "t", "", "test input to be stored, `-` reads it from stdin")
+	testAddCmd.Flags().String("test_file", "", "path of file holding the test input to be stored, `-` reads it from stdin")
+	testAddCmd.Flags().StringP("expected", "e", "", "expected answer of the test input")
+	testRecordCmd.Flags().StringP("file", "f", "", "path of a solution file to be interpreted")
+	testRecordCmd.Flags().Bool("refresh", false, "record again the test cases already holding an expected answer")
+	testRecordCmd.Flags().Duration("timeout", defaultJudgeTimeout, "maximum time to wait for the judge result")
+}
+
+var testCmd = &cobra.Command{
+	Use:   `test <commands>`,
+	Short: `Manage custom test cases`,
+	Long: `Manage the custom test cases of a problem, stored in the workspace as
+tests/<id>.txt and interpreted along with the problem examples`,
+}
+
+var testAddCmd = &cobra.Command{
+	Use:   `add`,
+	Short: `Add a test case`,
+	Args:  arg.TestAdd,
+	RunE:  testAdd,
+}
+
+var testListCmd = &cobra.Command{
+	Use:     `list`,
+	Aliases: []string{`ls`},
+	Short:   `List test cases`,
+	Args:    arg.TestList,
+	RunE:    testList,
+}
+
+var testRemoveCmd = &cobra.Command{
+	Use:     `rm <number>...`,
+	Aliases: []string{`remove`},
+	Short:   `Remove test cases by number`,
+	Args:    arg.TestRemove,
+	RunE:    testRemove,
+}
+
+var testRecordCmd = &cobra.Command{
+	Use:   `record`,
+	Short: `Record expected answers from the judge`,
+	Long: `Interpret the stored test cases once and record the answers of the
+official solution as their expected answers`,
+	Args: arg.TestRecord,
+	RunE: testRecord,
+}
+
+func testAdd(cmd *cobra.Command, args []string) error {
+	id, _ := cmd.Flags().GetInt("id")
+	expected, _ := cmd.Flags().GetString("expected")
+
+	input, err := readInput(cmd)
+	if err != nil {
+		return err
+	}
+	if input == "" {
+		return &arg.FlagError{Err: fmt.Errorf("invalid arguments: empty test input")}
+	}
+
+	suite, err := testcase.Load(id)
+	if err != nil {
+		return err
+	}
+
+	if !suite.Add(testcase.Case{Input: input, Expected: expected, Source: "manual"}) {
+		fmt.Fprintf(cmd.OutOrStdout(), "test case already stored for problem %d\n", id)
+		return suite.Save()
+	}
+
+	err = suite.Save()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "added test case %d to %s\n", len(suite.Cases), testcase.Path(id))
+	return nil
+}
+
+func testList(cmd *cobra.Command, args []string) error {
+	id, _ := cmd.Flags().GetInt("id")
+
+	suite, err := testcase.Load(id)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if len(suite.Cases) == 0 {
+		fmt.Fprintf(w, "no test case stored for problem %d\n", id)
+		return nil
+	}
+
+	for i, c := range suite.Cases {
+		title := fmt.Sprintf("Case %d", i+1)
+		if c.Source != "" {
+			title += utils.Gray(fmt.Sprintf(" (%s)", c.Source))
+		}
+		fmt.Fprintf(w, "%s\n%s\n", utils.Cyan(title), c.Input)
+		if c.Expected != "" {
+			fmt.Fprintf(w, "=> %s\n", c.Expected)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+func testRemove(cmd *cobra.Command, args []string) error {
+	id, _ := cmd.Flags().GetInt("id")
+
+	suite, err := testcase.Load(id)
+	if err != nil {
+		return err
+	}
+
+	// remove from the highest number so that lower numbers stay valid
+	numbers := make([]int, 0, len(args))
+	for _, a := range args {
+		n, _ := strconv.Atoi(a)
+		numbers = append(numbers, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(numbers)))
+
+	removed := 0
+	for i, n := range numbers {
+		if i > 0 && n == numbers[i-1] {
+			continue
+		}
+		err = suite.Remove(n)
+		if err != nil {
+			return err
+		}
+		removed++
+	}
+
+	err = suite.Save()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "removed %d test case(s), %d left\n", removed, len(suite.Cases))
+	return nil
+}
+
+func testRecord(cmd *cobra.Command, args []string) error {
+	id, _ := cmd.Flags().GetInt("id")
+	file, _ := cmd.Flags().GetString("file")
+	refresh, _ := cmd.Flags().GetBool("refresh")
+
+	fp, err := filepath.Abs(file)
+	if err != nil {
+		return err
+	}
+
+	suite, err := testcase.Load(id)
+	if err != nil {
+		return err
+	}
+	if len(suite.Cases) == 0 {
+		return fmt.Errorf("no test case stored for problem %d", id)
+	}
+
+	client, err := api.GetAuthClient()
+	if err != nil {
+		return err
+	}
+
+	problemDetail, err := client.GetProblemDetail(id, false)
+	if err != nil {
+		return err
+	}
+
+	// split blocks holding several inputs so that each gets its own answer
+	var cases []testcase.Case
+	for _, c := range suite.Cases {
+		inputs := problemDetail.SplitTestCases(c.Input)
+		if len(inputs) == 1 {
+			cases = append(cases, c)
+			continue
+		}
+		for _, input := range inputs {
+			cases = append(cases, testcase.Case{Input: input, Source: c.Source})
+		}
+	}
+	suite.Cases = cases
+
+	var pending []int
+	var inputs []string
+	for i, c := range suite.Cases {
+		if c.Expected == "" || refresh {
+			pending = append(pending, i)
+			inputs = append(inputs, c.Input)
+		}
+	}
+	if len(pending) == 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "every test case of problem %d already has an expected answer\n", id)
+		return suite.Save()
+	}
+
+	sClient, err := api.GetSubmitClient(problemDetail)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := judgeContext(cmd)
+	defer cancel()
+
+	result, err := interpretBatches(ctx, sClient, problemDetail, fp, inputs)
+	if err != nil {
+		return pendingHint(err)
+	}
+	if len(result.ExpectedCodeAnswer) != len(inputs) {
+		return fmt.Errorf(
+			"judge returned %d expected answers for %d test cases: %s",
+			len(result.ExpectedCodeAnswer),
+			len(inputs),
+			result.Verdict(),
+		)
+	}
+
+	for k, i := range pending {
+		c := &suite.Cases[i]
+		answer := result.ExpectedCodeAnswer[k]
+		if c.Expected != "" && !diff.Compare(c.Expected, answer).Equal {
+			fmt.Fprintf(
+				cmd.ErrOrStderr(),
+				"%s\n",
+				utils.Yellow(fmt.Sprintf("test case %d: expected answer changed from %s to %s", i+1, c.Expected, answer)),
+			)
+		}
+		c.Expected = answer
+	}
+
+	err = suite.Save()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "recorded %d expected answer(s) in %s\n", len(pending), testcase.Path(id))
+	return nil
+}
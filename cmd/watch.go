@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/arg"
+	"github.com/ckidckidckid/leetcode-cli/pkg/journal"
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+func init() {
+	RootCmd.AddCommand(watchCmd)
+	watchCmd.Flags().IntP("id", "i", 0, "ID of problem to be interpreted, inferred from the file by default")
+	watchCmd.Flags().StringP("file", "f", "", "path of file to be watched")
+	watchCmd.Flags().StringP("test_input", "t", "", "test input to be interpreted")
+	watchCmd.Flags().String("test_file", "", "path of file holding the test input to be interpreted")
+	watchCmd.Flags().Bool("submit-on-pass", false, "submit the solution once every test case passes")
+	watchCmd.Flags().Bool("save-failing", true, "store the failing test case of a rejected submission in the test case library")
+	watchCmd.Flags().Bool("stdout-only", false, "print nothing but the stdout of the solution")
+	watchCmd.Flags().Int("stdout-limit", defaultStdoutLimit, "maximum stdout lines shown per test case, 0 shows everything")
+	watchCmd.Flags().Duration("debounce", 300*time.Millisecond, "quiet period after a save before the solution is interpreted")
+	watchCmd.Flags().Duration("timeout", defaultJudgeTimeout, "maximum time to wait for each judge result")
+}
+
+var watchCmd = &cobra.Command{
+	Use:   `watch [file]`,
+	Short: `Interpret code on every save`,
+	Long: `Watch a local solution file and interpret it again every time it is saved
+
+Test input is resolved like 'lc interpret' on every run, so test cases added
+with 'lc test add' are picked up. A save during a run cancels it and starts
+over. With --submit-on-pass the solution is submitted once every test case
+passes. Press Ctrl+C to stop watching.`,
+	Args: arg.Watch,
+	RunE: watch,
+}
+
+func watch(cmd *cobra.Command, args []string) error {
+	debounce, _ := cmd.Flags().GetDuration("debounce")
+
+	fp, id, err := resolveSource(cmd, args)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(fp); err != nil {
+		return err
+	}
+
+	client, err := api.GetAuthClient()
+	if err != nil {
+		return err
+	}
+
+	problemDetail, err := client.GetProblemDetail(id, false)
+	if err != nil {
+		return err
+	}
+
+	sClient, err := api.GetSubmitClient(problemDetail)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// editors often save by renaming a new file over the old one, so the
+	// directory is watched rather than the file itself
+	err = watcher.Add(filepath.Dir(fp))
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	w := &watchRunner{cmd: cmd, client: sClient, pd: problemDetail, id: id, fp: fp}
+	err = watchLoop(ctx, watcher.Events, watcher.Errors, fp, debounce, w)
+	if ctx.Err() != nil {
+		fmt.Fprintln(cmd.ErrOrStderr(), "stopped watching")
+	}
+	return err
+}
+
+// restarter runs one job at a time, watchLoop drives it
+type restarter interface {
+	start(ctx context.Context)
+	cancelAndWait()
+}
+
+// watchLoop starts r, then starts it over once no event about fp came for
+// debounce, coalescing bursts of saves into one run; it returns once ctx is
+// done or the event channels fail, the run in flight cancelled
+func watchLoop(ctx context.Context, events <-chan fsnotify.Event, errs <-chan error, fp string, debounce time.Duration, r restarter) error {
+	r.start(ctx)
+	defer r.cancelAndWait()
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != fp || event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+				continue
+			}
+			timer.Reset(debounce)
+		case err, ok := <-errs:
+			if !ok {
+				return nil
+			}
+			return err
+		case <-timer.C:
+			r.cancelAndWait()
+			r.start(ctx)
+		}
+	}
+}
+
+// watchRunner runs one interpretation at a time, a new run cancels the one in
+// flight
+type watchRunner struct {
+	cmd    *cobra.Command
+	client *api.Client
+	pd     *model.ProblemDetail
+	id     int
+	fp     string
+
+	runs   int
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (w *watchRunner) start(ctx context.Context) {
+	timeout, _ := w.cmd.Flags().GetDuration("timeout")
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+
+	w.runs++
+	w.cancel = cancel
+	w.done = make(chan struct{})
+
+	go func(run int, done chan struct{}) {
+		defer close(done)
+		defer cancel()
+		w.run(ctx, run)
+	}(w.runs, w.done)
+}
+
+func (w *watchRunner) cancelAndWait() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	<-w.done
+	w.cancel = nil
+}
+
+// run interprets the solution and redraws the result panel
+func (w *watchRunner) run(ctx context.Context, run int) {
+	out := w.cmd.OutOrStdout()
+	fmt.Fprint(out, clearScreen)
+	fmt.Fprintf(
+		out,
+		"%s\n\n",
+		utils.Gray(fmt.Sprintf("watching %s, run %d at %s", filepath.Base(w.fp), run, time.Now().Format("15:04:05"))),
+	)
+
+	err := w.interpretAndSubmit(ctx, out)
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(out, utils.Red(pendingHint(err).Error()))
+	}
+	fmt.Fprintf(out, "\n%s\n", utils.Gray("waiting for changes, press Ctrl+C to stop"))
+}
+
+func (w *watchRunner) interpretAndSubmit(ctx context.Context, out io.Writer) error {
+	testCases, err := interpretInput(w.cmd, w.pd)
+	if err != nil {
+		return err
+	}
+
+	result, err := interpretBatches(ctx, w.client, w.pd, w.fp, testCases)
+	if err != nil {
+		return err
+	}
+	err = expectStored(w.pd, result)
+	if err != nil {
+		return err
+	}
+	recordInterpretation(w.cmd, w.pd, w.fp, result)
+
+	opts := renderOptionsFromFlags(w.cmd)
+	opts.LineOffset = sourceLineOffset(w.fp)
+	renderInterpretation(out, result, opts)
+
+	submitOnPass, _ := w.cmd.Flags().GetBool("submit-on-pass")
+	if !submitOnPass || result.Verdict() != api.VerdictAccepted {
+		return nil
+	}
+
+	payload, err := api.NewSubmitPayload(w.pd, w.fp)
+	if err != nil {
+		return err
+	}
+
+	// a save without changes must not submit the same code again, whatever
+	// the duplicateSubmissions setting
+	duplicate, err := findDuplicate(w.id, payload.Lang, journal.HashCode(payload.TypedCode))
+	if err != nil {
+		return err
+	}
+	if duplicate != "" {
+		fmt.Fprintf(out, "\n%s\n", utils.Yellow("not submitting, "+duplicate))
+		return nil
+	}
+
+	fmt.Fprintf(out, "\n%s\n\n", utils.Blue("All test cases passed, submitting"))
+	submissionID, err := w.client.PostSubmission(ctx, w.pd, payload)
+	if err != nil {
+		return err
+	}
+	pending := recordPendingSubmission(w.cmd, w.id, w.fp, payload, submissionID)
+
+	sr, err := w.client.CheckSubmission(ctx, submissionID)
+	if errors.Is(err, context.Canceled) {
+		fmt.Fprintf(out, "%s\n", utils.Gray(fmt.Sprintf("stopped waiting for submission %s, run `lc check %s` to fetch its result", submissionID, submissionID)))
+	}
+	if err != nil {
+		return err
+	}
+	recordSubmission(w.cmd, pending, sr)
+	renderSubmission(out, sr, opts)
+
+	if saveFailing, _ := w.cmd.Flags().GetBool("save-failing"); saveFailing {
+		warnSaveFailing(w.cmd, saveFailingCase(w.cmd, w.id, sr))
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const testDebounce = 50 * time.Millisecond
+
+// fakeRestarter reports every start on starts and counts cancellations
+type fakeRestarter struct {
+	starts  chan struct{}
+	mu      sync.Mutex
+	cancels int
+}
+
+func (r *fakeRestarter) start(ctx context.Context) {
+	r.starts <- struct{}{}
+}
+
+func (r *fakeRestarter) cancelAndWait() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cancels++
+}
+
+// loopTest runs watchLoop on fake event channels until the test ends
+type loopTest struct {
+	t      *testing.T
+	r      *fakeRestarter
+	events chan fsnotify.Event
+	errs   chan error
+	cancel context.CancelFunc
+	done   chan error
+}
+
+func newLoopTest(t *testing.T) *loopTest {
+	ctx, cancel := context.WithCancel(context.Background())
+	lt := &loopTest{
+		t:      t,
+		r:      &fakeRestarter{starts: make(chan struct{}, 16)},
+		events: make(chan fsnotify.Event),
+		errs:   make(chan error),
+		cancel: cancel,
+		done:   make(chan error, 1),
+	}
+	go func() {
+		lt.done <- watchLoop(ctx, lt.events, lt.errs, "/w/solution.go", testDebounce, lt.r)
+	}()
+	t.Cleanup(cancel)
+	lt.expectStart()
+	return lt
+}
+
+func (lt *loopTest) save(name string, op fsnotify.Op) {
+	lt.events <- fsnotify.Event{Name: name, Op: op}
+}
+
+func (lt *loopTest) expectStart() {
+	lt.t.Helper()
+	select {
+	case <-lt.r.starts:
+	case <-time.After(20 * testDebounce):
+		lt.t.Fatal("no run started")
+	}
+}
+
+func (lt *loopTest) expectNoStart(wait time.Duration) {
+	lt.t.Helper()
+	select {
+	case <-lt.r.starts:
+		lt.t.Fatal("unexpected run started")
+	case <-time.After(wait):
+	}
+}
+
+func TestWatchLoopDebounce(t *testing.T) {
+	lt := newLoopTest(t)
+
+	lt.save("/w/solution.go", fsnotify.Write)
+	lt.expectNoStart(testDebounce / 2)
+	lt.expectStart()
+	lt.expectNoStart(3 * testDebounce)
+}
+
+func TestWatchLoopCoalesce(t *testing.T) {
+	lt := newLoopTest(t)
+
+	for i := 0; i < 5; i++ {
+		lt.save("/w/solution.go", fsnotify.Write)
+		time.Sleep(testDebounce / 5)
+	}
+	lt.expectStart()
+	lt.expectNoStart(3 * testDebounce)
+}
+
+func TestWatchLoopIgnoresOtherEvents(t *testing.T) {
+	lt := newLoopTest(t)
+
+	lt.save("/w/other.go", fsnotify.Write)
+	lt.save("/w/solution.go", fsnotify.Chmod)
+	lt.expectNoStart(3 * testDebounce)
+
+	// editors saving by rename create the file anew
+	lt.save("/w/solution.go", fsnotify.Create)
+	lt.expectStart()
+}
+
+func TestWatchLoopCancel(t *testing.T) {
+	lt := newLoopTest(t)
+
+	lt.save("/w/solution.go", fsnotify.Write)
+	lt.cancel()
+	select {
+	case err := <-lt.done:
+		if err != nil {
+			t.Errorf("watchLoop = %v, want nil", err)
+		}
+	case <-time.After(20 * testDebounce):
+		t.Fatal("watchLoop did not return once cancelled")
+	}
+	lt.expectNoStart(3 * testDebounce)
+
+	lt.r.mu.Lock()
+	defer lt.r.mu.Unlock()
+	if lt.r.cancels != 1 {
+		t.Errorf("%d runs cancelled, want the one in flight", lt.r.cancels)
+	}
+}
+
+func TestWatchLoopError(t *testing.T) {
+	lt := newLoopTest(t)
+
+	want := errors.New("watch limit reached")
+	lt.errs <- want
+	if err := <-lt.done; err != want {
+		t.Errorf("watchLoop = %v, want %v", err, want)
+	}
+}
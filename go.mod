@@ -3,11 +3,13 @@ module github.com/ckidckidckid/leetcode-cli
 go 1.19
 
 require (
+	github.com/fsnotify/fsnotify v1.6.0
 	github.com/go-rod/rod v0.112.2
 	github.com/kyokomi/emoji v2.2.4+incompatible
 	github.com/mgutz/ansi v0.0.0-20200706080929-d51e80ef957d
 	github.com/spf13/cobra v1.6.1
 	golang.org/x/crypto v0.4.0
+	golang.org/x/term v0.3.0
 )
 
 require (
@@ -19,5 +21,4 @@ require (
 	github.com/ysmood/gson v0.7.3 // indirect
 	github.com/ysmood/leakless v0.8.0 // indirect
 	golang.org/x/sys v0.3.0 // indirect
-	golang.org/x/term v0.3.0 // indirect
 )
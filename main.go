@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,13 +13,23 @@ import (
 var updaterEnabled = ""
 
 func main() {
-	if cmd, err := cmd.RootCmd.ExecuteC(); err != nil {
-		printError(os.Stderr, err, cmd)
+	c, err := cmd.RootCmd.ExecuteC()
+	if err != nil {
+		printError(os.Stderr, err, c)
 	}
+	os.Exit(cmd.ExitCode(err))
 }
 
-func printError(out io.Writer, err error, cmd *cobra.Command) {
+func printError(out io.Writer, err error, c *cobra.Command) {
+	// rejected verdicts have already been rendered on stdout
+	var ve *cmd.VerdictError
+	if errors.As(err, &ve) {
+		return
+	}
+
 	fmt.Fprintln(out, err)
-	fmt.Fprintln(out)
-	fmt.Fprintln(out, cmd.UsageString())
+	if cmd.IsUsageError(err) {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, c.UsageString())
+	}
 }
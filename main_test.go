@@ -0,0 +1,474 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/lctest"
+)
+
+// runMainEnv makes the test binary behave as the cli, so that every command
+// runs in a fresh process reading its LC_* environment
+const runMainEnv = "LC_TEST_RUN_MAIN"
+
+var server *lctest.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv(runMainEnv) == "1" {
+		main()
+		return
+	}
+
+	var err error
+	server, err = lctest.NewServer()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
+// workspace is a configured directory commands run in, with its own journal
+// and test case library
+type workspace struct {
+	t   *testing.T
+	dir string
+	env []string
+}
+
+func newWorkspace(t *testing.T) *workspace {
+	dir := t.TempDir()
+	config := filepath.Join(dir, ".lc")
+	if err := lctest.WriteConfig(config); err != nil {
+		t.Fatal(err)
+	}
+
+	env := append(os.Environ(), runMainEnv+"=1", "LC_DATA_DIR="+config, "LC_TESTS_DIR="+filepath.Join(dir, "tests"))
+	env = append(env, server.Environ(config)...)
+	return &workspace{t: t, dir: dir, env: env}
+}
+
+// run runs the cli with args, returning its stdout, stderr and exit code
+func (w *workspace) run(args ...string) (string, string, int) {
+	w.t.Helper()
+	c := exec.Command(os.Args[0], args...)
+	c.Dir = w.dir
+	c.Env = w.env
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	err := c.Run()
+	if _, ok := err.(*exec.ExitError); err != nil && !ok {
+		w.t.Fatalf("lc %s: %v", strings.Join(args, " "), err)
+	}
+	return stdout.String(), stderr.String(), c.ProcessState.ExitCode()
+}
+
+// mustRun runs the cli with args, failing the test unless it exits with want
+func (w *workspace) mustRun(want int, args ...string) string {
+	w.t.Helper()
+	stdout, stderr, code := w.run(args...)
+	if code != want {
+		w.t.Fatalf("lc %s: exit code %d, want %d\nstdout:\n%s\nstderr:\n%s", strings.Join(args, " "), code, want, stdout, stderr)
+	}
+	return stdout
+}
+
+// runJSON runs the cli with args and `-o json`, decoding its output into v
+func (w *workspace) runJSON(want int, v interface{}, args ...string) {
+	w.t.Helper()
+	stdout := w.mustRun(want, append(args, "-o", "json")...)
+	if err := json.Unmarshal([]byte(stdout), v); err != nil {
+		w.t.Fatalf("lc %s: %v\n%s", strings.Join(args, " "), err, stdout)
+	}
+}
+
+// solution exports problem 1 in Go with marker inserted into its code region
+func (w *workspace) solution(marker string) string {
+	w.t.Helper()
+	w.mustRun(0, "show", "-i", "1", "-l", "golang")
+
+	fp := filepath.Join(w.dir, "0001_two-sum.go")
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		w.t.Fatal(err)
+	}
+	code := strings.Replace(string(b), "@lc code=start", "@lc code=start\n// "+marker, 1)
+	if err := os.WriteFile(fp, []byte(code), 0644); err != nil {
+		w.t.Fatal(err)
+	}
+	return fp
+}
+
+type result struct {
+	ID        string `json:"id"`
+	Verdict   string `json:"verdict"`
+	TestCases struct {
+		Passed int `json:"passed"`
+		Total  int `json:"total"`
+	} `json:"testCases"`
+	Cases []struct {
+		Input  string `json:"input"`
+		Passed bool   `json:"passed"`
+		Stdout string `json:"stdout"`
+	} `json:"cases"`
+}
+
+func TestList(t *testing.T) {
+	w := newWorkspace(t)
+	stdout := w.mustRun(0, "list")
+	for _, title := range []string{"Two Sum", "Palindrome Number"} {
+		if !strings.Contains(stdout, title) {
+			t.Errorf("list lacks %q:\n%s", title, stdout)
+		}
+	}
+}
+
+func TestShow(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest")
+
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"@lc id=1 slug=two-sum lang=golang", "@lc code=start", "@lc code=end", "func twoSum("} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("exported source lacks %q:\n%s", want, b)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(w.dir, "0001_two-sum.md")); err != nil {
+		t.Errorf("description not exported: %v", err)
+	}
+}
+
+func TestInterpretBatches(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest:stdout=1")
+
+	var input strings.Builder
+	for i := 0; i < 12; i++ {
+		fmt.Fprintf(&input, "[%d,7]\\n%d\\n", i, i+7)
+	}
+	before := len(server.Requests())
+
+	var r result
+	w.runJSON(0, &r, "interpret", fp, "-t", input.String())
+	if r.Verdict != "accepted" {
+		t.Errorf("verdict %q, want accepted", r.Verdict)
+	}
+	if len(r.Cases) != 12 {
+		t.Fatalf("%d cases, want 12", len(r.Cases))
+	}
+	if r.TestCases.Passed != 12 || r.TestCases.Total != 12 {
+		t.Errorf("test cases %+v, want 12 of 12 passed", r.TestCases)
+	}
+	if n := len(server.Requests()) - before; n != 2 {
+		t.Errorf("%d judge requests, want 2", n)
+	}
+	for i, c := range r.Cases {
+		if want := fmt.Sprintf("[%d,7]\n%d", i, i+7); c.Input != want {
+			t.Errorf("case %d input %q, want %q", i+1, c.Input, want)
+		}
+		if want := fmt.Sprintf("case %d: debug line 1\n", i%10+1); c.Stdout != want {
+			t.Errorf("case %d stdout %q, want %q", i+1, c.Stdout, want)
+		}
+	}
+}
+
+func TestInterpretWrongAnswer(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest:verdict=wrong_answer")
+
+	var r result
+	w.runJSON(3, &r, "interpret", fp)
+	if r.Verdict != "wrong_answer" {
+		t.Errorf("verdict %q, want wrong_answer", r.Verdict)
+	}
+	for i, c := range r.Cases {
+		if c.Passed {
+			t.Errorf("case %d passed", i+1)
+		}
+	}
+}
+
+func TestSubmitAndCheck(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("accepted")
+
+	var submitted result
+	w.runJSON(0, &submitted, "submit", fp)
+	if submitted.Verdict != "accepted" || submitted.ID == "" {
+		t.Fatalf("submitted %+v, want an accepted submission", submitted)
+	}
+
+	var checked result
+	w.runJSON(0, &checked, "check", submitted.ID)
+	if checked.ID != submitted.ID || checked.Verdict != "accepted" {
+		t.Errorf("checked %+v, want %+v", checked, submitted)
+	}
+
+	_, stderr, code := w.run("submit", fp)
+	if code != 1 || !strings.Contains(stderr, "--force") {
+		t.Errorf("resubmission exit code %d, want 1 with a duplicate error:\n%s", code, stderr)
+	}
+}
+
+func TestSubmitRejected(t *testing.T) {
+	for verdict, code := range map[string]int{
+		"wrong_answer":        3,
+		"compile_error":       4,
+		"runtime_error":       5,
+		"time_limit_exceeded": 6,
+	} {
+		t.Run(verdict, func(t *testing.T) {
+			w := newWorkspace(t)
+			fp := w.solution("lctest:verdict=" + verdict)
+
+			var r result
+			w.runJSON(code, &r, "submit", fp)
+			if r.Verdict != verdict {
+				t.Errorf("verdict %q, want %q", r.Verdict, verdict)
+			}
+		})
+	}
+}
+
+func TestInterpretStoredExpected(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest")
+	tests := filepath.Join(w.dir, "tests")
+	if err := os.MkdirAll(tests, 0755); err != nil {
+		t.Fatal(err)
+	}
+	library := "[3,2,4]\n6\n=> [2,1]\n\n[3,3]\n6\n=> [0, 1]\n"
+	if err := os.WriteFile(filepath.Join(tests, "1.txt"), []byte(library), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var r result
+	w.runJSON(3, &r, "interpret", fp)
+	if r.Verdict != "wrong_answer" {
+		t.Errorf("verdict %q, want wrong_answer", r.Verdict)
+	}
+	passed := []bool{true, false, true}
+	if len(r.Cases) != len(passed) {
+		t.Fatalf("%d cases, want %d", len(r.Cases), len(passed))
+	}
+	for i, c := range r.Cases {
+		if c.Passed != passed[i] {
+			t.Errorf("case %d passed = %v, want %v", i+1, c.Passed, passed[i])
+		}
+	}
+
+	_, stderr, code := w.run("submit", fp, "--verify")
+	if code != 3 || !strings.Contains(stderr, "test case 2") || !strings.Contains(stderr, "stored expected answer") {
+		t.Errorf("verified submission exit code %d, want 3 blocked on test case 2:\n%s", code, stderr)
+	}
+}
+
+func TestPull(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest:pull")
+	w.mustRun(0, "submit", fp, "-o", "json")
+	accepted, err := os.ReadFile(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a later rejected submission must not be pulled
+	rejected := strings.Replace(string(accepted), "lctest:pull", "lctest:verdict=wrong_answer", 1)
+	if err := os.WriteFile(fp, []byte(rejected), 0644); err != nil {
+		t.Fatal(err)
+	}
+	w.mustRun(3, "submit", fp, "-o", "json")
+
+	if err := os.Remove(fp); err != nil {
+		t.Fatal(err)
+	}
+	stdout := w.mustRun(0, "pull", "-i", "1")
+	if !strings.Contains(stdout, "0001_two-sum.go") {
+		t.Errorf("pull output lacks the source file:\n%s", stdout)
+	}
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "lctest:pull") || strings.Contains(string(b), "lctest:verdict") {
+		t.Errorf("pulled source is not the accepted submission:\n%s", b)
+	}
+}
+
+func TestSubmitInterrupted(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest:interrupted")
+
+	_, stderr, code := w.run("submit", fp, "--timeout", "200ms")
+	if code != 9 || !strings.Contains(stderr, "lc check") {
+		t.Fatalf("submit exit code %d, want 9 with a check hint:\n%s", code, stderr)
+	}
+
+	var entries []struct {
+		SubmissionID string `json:"submissionId"`
+		Verdict      string `json:"verdict"`
+	}
+	w.runJSON(0, &entries, "history")
+	if len(entries) != 1 || entries[0].Verdict != "unknown" {
+		t.Fatalf("history = %+v, want the pending submission", entries)
+	}
+	if _, stderr, code := w.run("submit", fp); code != 1 || !strings.Contains(stderr, "still pending") {
+		t.Errorf("resubmission exit code %d, want 1 as a duplicate of the pending one:\n%s", code, stderr)
+	}
+
+	w.mustRun(0, "check", entries[0].SubmissionID)
+	id := entries[0].SubmissionID
+	w.runJSON(0, &entries, "history")
+	if len(entries) != 1 || entries[0].SubmissionID != id || entries[0].Verdict != "accepted" {
+		t.Errorf("history = %+v, want submission %s accepted", entries, id)
+	}
+}
+
+func TestSubmitSaveFailingWarns(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest:verdict=wrong_answer")
+	// a file in place of the test case library makes storing the case fail
+	tests := filepath.Join(w.dir, "tests")
+	if err := os.WriteFile(tests, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, stderr, code := w.run("submit", fp)
+	if code != 3 || !strings.Contains(stderr, "cannot store the failing test case") {
+		t.Errorf("submit exit code %d, want 3 with a warning:\n%s", code, stderr)
+	}
+}
+
+func TestCompileErrorLine(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest:verdict=compile_error")
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the judge counts from the @lc code region, line 4 in the fixture
+	var start int
+	for i, line := range strings.Split(string(b), "\n") {
+		if strings.Contains(line, "@lc code=start") {
+			start = i + 1
+			break
+		}
+	}
+
+	stdout := w.mustRun(4, "submit", fp)
+	if want := fmt.Sprintf("at line %d\n", start+4); !strings.Contains(stdout, want) {
+		t.Errorf("compile error lacks %q:\n%s", want, stdout)
+	}
+}
+
+func TestShowByExtension(t *testing.T) {
+	w := newWorkspace(t)
+	w.mustRun(0, "show", "-i", "1", "-l", "py")
+
+	b, err := os.ReadFile(filepath.Join(w.dir, "0001_two-sum.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "lang=python3") {
+		t.Errorf("exported source is not python3:\n%s", b)
+	}
+}
+
+func TestInterpretRuntimeError(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest:verdict=runtime_error")
+
+	stdout := w.mustRun(5, "interpret", fp)
+	if !strings.Contains(stdout, "Last Test Case") || !strings.Contains(stdout, `[2,7,11,15]\n9`) {
+		t.Errorf("runtime error lacks the failing input:\n%s", stdout)
+	}
+}
+
+func TestTestRemoveDuplicates(t *testing.T) {
+	w := newWorkspace(t)
+	w.mustRun(0, "test", "add", "-i", "1", "-t", `[1,2]\n3`)
+	w.mustRun(0, "test", "add", "-i", "1", "-t", `[1,3]\n4`)
+
+	stdout := w.mustRun(0, "test", "rm", "-i", "1", "2", "2")
+	if !strings.Contains(stdout, "removed 1 test case(s), 1 left") {
+		t.Errorf("rm output %q, want 1 removed", stdout)
+	}
+}
+
+func TestExport(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest:mine")
+	before := len(server.ProblemQueries())
+
+	stdout := w.mustRun(0, "export", "-i", "1", "-l", "golang")
+	stdout += w.mustRun(0, "export", "-i", "9", "-l", "golang")
+	for _, name := range []string{"0001_two-sum.md", "0009_palindrome-number.md", "0009_palindrome-number.go"} {
+		if _, err := os.Stat(filepath.Join(w.dir, name)); err != nil {
+			t.Errorf("%s not exported: %v\n%s", name, err, stdout)
+		}
+	}
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "lctest:mine") {
+		t.Errorf("export replaced the existing solution:\n%s", b)
+	}
+	// two-sum was cached by show
+	if queries := server.ProblemQueries()[before:]; len(queries) != 1 || queries[0] != "palindrome-number" {
+		t.Errorf("problem queries %v, want only palindrome-number", queries)
+	}
+}
+
+func TestCacheWarm(t *testing.T) {
+	w := newWorkspace(t)
+	before := len(server.ProblemQueries())
+
+	stdout := w.mustRun(0, "cache", "warm", "-i", "1")
+	stdout += w.mustRun(0, "cache", "warm", "-i", "9")
+	if !strings.Contains(stdout, "two-sum") || !strings.Contains(stdout, "palindrome-number") {
+		t.Errorf("cache warm output lacks the problems:\n%s", stdout)
+	}
+	if stdout := w.mustRun(0, "cache", "warm", "-i", "1"); stdout != "" {
+		t.Errorf("warming a cached problem printed %q", stdout)
+	}
+	w.mustRun(0, "show", "-i", "1")
+	if n := len(server.ProblemQueries()) - before; n != 2 {
+		t.Errorf("%d problem queries, want each problem fetched once", n)
+	}
+
+	w.mustRun(0, "cache", "clear")
+	w.mustRun(0, "show", "-i", "1")
+	if n := len(server.ProblemQueries()) - before; n != 3 {
+		t.Errorf("%d problem queries, want two-sum fetched again once cleared", n)
+	}
+}
+
+func TestInterpretEmptyStdin(t *testing.T) {
+	w := newWorkspace(t)
+	fp := w.solution("lctest")
+	before := len(server.Requests())
+
+	for _, flag := range []string{"--test_file", "--test_input"} {
+		_, stderr, code := w.run("interpret", fp, flag, "-")
+		if code != 1 || !strings.Contains(stderr, "no test input in stdin") {
+			t.Errorf("interpret %s - exit code %d, want 1 with an empty input error:\n%s", flag, code, stderr)
+		}
+	}
+	if n := len(server.Requests()) - before; n != 0 {
+		t.Errorf("%d judge requests, want none", n)
+	}
+}
@@ -50,11 +50,28 @@ func (a *Auth) SetAuthCredentials() error {
 	return nil
 }
 
-// GetAuthClient returns a basic API Client based on local auth config
-func GetAuthClient() (*Client, error) {
+// AuthError is returned when no usable leetcode session is configured
+type AuthError struct {
+	Err error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("not signed in, run `lc user signin` first: %s", e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// GetAuthClient returns a basic API Client based on local auth config, with
+// extra options applied after the auth headers and before retries
+func GetAuthClient(extra ...ClientOption) (*Client, error) {
 	a, err := GetAuthCredentials()
 	if err != nil {
-		return nil, err
+		return nil, &AuthError{Err: err}
+	}
+	if a.SessionID == "" || a.SessionCSRF == "" {
+		return nil, &AuthError{Err: fmt.Errorf("no session in %s", utils.AuthConfigPath)}
 	}
 
 	var opts []ClientOption
@@ -81,6 +98,10 @@ func GetAuthClient() (*Client, error) {
 		AddHeader("X-CSRFToken", a.SessionCSRF),
 	)
 
+	// retried attempts go through the extra options again, e.g. Throttle
+	opts = append(opts, extra...)
+	opts = append(opts, Retry(DefaultRetryPolicy))
+
 	return NewClient(opts...), nil
 }
 
@@ -186,5 +207,7 @@ func GetSubmitClient(pd *model.ProblemDetail) (*Client, error) {
 		AddHeader("Referer", strings.Replace(utils.SubmitRefererURL, "$slug", pd.TitleSlug, 1)),
 	)
 
+	opts = append(opts, Retry(DefaultRetryPolicy))
+
 	return NewClient(opts...), nil
 }
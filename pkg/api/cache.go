@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+// problemCacheTTL bounds the age of a cached problem detail, problems rarely
+// change once published
+const problemCacheTTL = 7 * 24 * time.Hour
+
+// problemCachePath returns the cache file of the problem titled slug
+func problemCachePath(titleSlug string) string {
+	return filepath.Join(utils.ProblemCacheDir, titleSlug+".json")
+}
+
+// cachedProblemDetail returns the cached problem titled slug, unless it is
+// missing or older than problemCacheTTL
+func cachedProblemDetail(titleSlug string) (*model.ProblemDetail, bool) {
+	fp := problemCachePath(titleSlug)
+	info, err := os.Stat(fp)
+	if err != nil || time.Since(info.ModTime()) > problemCacheTTL {
+		return nil, false
+	}
+
+	b, err := os.ReadFile(fp)
+	if err != nil {
+		return nil, false
+	}
+	pd := &model.ProblemDetail{}
+	if err := json.Unmarshal(b, pd); err != nil || pd.TitleSlug != titleSlug {
+		return nil, false
+	}
+	return pd, true
+}
+
+// cacheProblemDetail stores pd in the problem cache, replacing the cache file
+// at once so that concurrent readers never see it partially written
+func cacheProblemDetail(pd *model.ProblemDetail) error {
+	err := os.MkdirAll(utils.ProblemCacheDir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(pd)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(utils.ProblemCacheDir, pd.TitleSlug+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), problemCachePath(pd.TitleSlug))
+}
+
+// ClearProblemCache removes every cached problem detail
+func ClearProblemCache() error {
+	return os.RemoveAll(utils.ProblemCacheDir)
+}
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/model"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+func TestProblemCache(t *testing.T) {
+	dir := utils.ProblemCacheDir
+	utils.ProblemCacheDir = t.TempDir()
+	defer func() { utils.ProblemCacheDir = dir }()
+
+	if _, ok := cachedProblemDetail("two-sum"); ok {
+		t.Fatal("empty cache hit")
+	}
+	err := cacheProblemDetail(&model.ProblemDetail{QuestionFrontendID: "1", TitleSlug: "two-sum"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pd, ok := cachedProblemDetail("two-sum")
+	if !ok || pd.QuestionFrontendID != "1" {
+		t.Fatalf("cached problem = %+v, %v, want two-sum", pd, ok)
+	}
+
+	stale := time.Now().Add(-problemCacheTTL - time.Hour)
+	if err := os.Chtimes(problemCachePath("two-sum"), stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cachedProblemDetail("two-sum"); ok {
+		t.Error("stale cache hit")
+	}
+
+	if err := ClearProblemCache(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(utils.ProblemCacheDir); !os.IsNotExist(err) {
+		t.Errorf("cache directory left after clear: %v", err)
+	}
+}
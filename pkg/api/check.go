@@ -0,0 +1,89 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+// pollBackoff is the delay schedule between two judge check polls, the last
+// delay is repeated until the judge finishes or the context is done
+var pollBackoff = []time.Duration{
+	500 * time.Millisecond,
+	1 * time.Second,
+	1 * time.Second,
+	2 * time.Second,
+	2 * time.Second,
+	3 * time.Second,
+}
+
+// PendingError is returned when polling stops before the judge finished,
+// ID can be checked later with CheckSubmission or CheckInterpretation
+type PendingError struct {
+	ID  string
+	Err error
+}
+
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("stopped waiting for judge result of %s: %s", e.ID, e.Err)
+}
+
+func (e *PendingError) Unwrap() error {
+	return e.Err
+}
+
+type judgeState interface {
+	judgeState() string
+}
+
+func (sr *SubmissionResult) judgeState() string {
+	return sr.State
+}
+
+func (ir *InterpretResult) judgeState() string {
+	return ir.State
+}
+
+// waitForJudge polls the check endpoint of submission or interpretation id
+// with pollBackoff until the judge reports SUCCESS, filling result
+func (c *Client) waitForJudge(ctx context.Context, id string, result judgeState) error {
+	url := strings.Replace(utils.VerifyURL, "$id", id, 1)
+
+	for attempt := 0; ; attempt++ {
+		err := c.RESTWithContext(ctx, "GET", url, nil, result)
+		if err != nil {
+			if ctx.Err() != nil {
+				return &PendingError{ID: id, Err: ctx.Err()}
+			}
+			return err
+		}
+
+		switch result.judgeState() {
+		case "PENDING", "STARTED":
+		case "SUCCESS":
+			return nil
+		default:
+			return fmt.Errorf("failure code submission. unexpected submission state: %s", result.judgeState())
+		}
+
+		delay := pollBackoff[len(pollBackoff)-1]
+		if attempt < len(pollBackoff) {
+			delay = pollBackoff[attempt]
+		}
+
+		select {
+		case <-ctx.Done():
+			return &PendingError{ID: id, Err: ctx.Err()}
+		case <-time.After(delay):
+		}
+	}
+}
+
+// IsInterpretID reports whether id belongs to an interpretation rather than
+// a submission
+func IsInterpretID(id string) bool {
+	return strings.HasPrefix(id, "runcode_")
+}
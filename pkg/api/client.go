@@ -2,11 +2,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
@@ -49,6 +51,24 @@ func ReplaceTripper(tr http.RoundTripper) ClientOption {
 	}
 }
 
+// Limiter delays requests to respect a rate limit
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// Throttle turns a RoundTripper into one waiting for l before every request
+func Throttle(l Limiter) ClientOption {
+	return func(tr http.RoundTripper) http.RoundTripper {
+		return &funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+			err := l.Wait(req.Context())
+			if err != nil {
+				return nil, err
+			}
+			return tr.RoundTrip(req)
+		}}
+	}
+}
+
 type funcTripper struct {
 	roundTrip func(*http.Request) (*http.Response, error)
 }
@@ -89,6 +109,12 @@ func (gr GraphQLErrorResponse) Error() string {
 
 // GraphQL performs a GraphQL request and parses the response
 func (c Client) GraphQL(operationName string, query string, variables map[string]interface{}, data interface{}) error {
+	return c.GraphQLWithContext(context.Background(), operationName, query, variables, data)
+}
+
+// GraphQLWithContext performs a GraphQL request bound to ctx and parses the
+// response
+func (c Client) GraphQLWithContext(ctx context.Context, operationName string, query string, variables map[string]interface{}, data interface{}) error {
 	reqBody, err := json.Marshal(
 		map[string]interface{}{
 			"operationName": operationName,
@@ -100,7 +126,12 @@ func (c Client) GraphQL(operationName string, query string, variables map[string
 		return err
 	}
 
-	req, err := http.NewRequest("POST", utils.GraphQLURL, bytes.NewBuffer(reqBody))
+	// queries can be sent again, unlike mutations
+	if !strings.HasPrefix(strings.TrimSpace(query), "mutation") {
+		ctx = withIdempotent(ctx)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", utils.GraphQLURL, bytes.NewBuffer(reqBody))
 	if err != nil {
 		return err
 	}
@@ -118,7 +149,12 @@ func (c Client) GraphQL(operationName string, query string, variables map[string
 
 // REST performs a REST request and parses the response.
 func (c Client) REST(method string, url string, body io.Reader, data interface{}) error {
-	req, err := http.NewRequest(method, url, body)
+	return c.RESTWithContext(context.Background(), method, url, body, data)
+}
+
+// RESTWithContext performs a REST request bound to ctx and parses the response.
+func (c Client) RESTWithContext(ctx context.Context, method string, url string, body io.Reader, data interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return err
 	}
@@ -153,6 +189,29 @@ func (c Client) REST(method string, url string, body io.Reader, data interface{}
 	return nil
 }
 
+// HTTPError is an error returned by a failed API call
+type HTTPError struct {
+	StatusCode int
+	RequestURL *url.URL
+	Message    string
+}
+
+func (err HTTPError) Error() string {
+	return fmt.Sprintf("http error, '%s' failed (%d): '%s'", err.RequestURL, err.StatusCode, err.Message)
+}
+
+// IsRateLimited reports whether the request was rejected for being sent too
+// soon or too often
+func (err HTTPError) IsRateLimited() bool {
+	return err.StatusCode == http.StatusTooManyRequests
+}
+
+// IsAuthFailure reports whether the request was rejected for lack of a
+// valid session
+func (err HTTPError) IsAuthFailure() bool {
+	return err.StatusCode == http.StatusUnauthorized || err.StatusCode == http.StatusForbidden
+}
+
 func handleResponse(resp *http.Response, data interface{}) error {
 	success := resp.StatusCode >= 200 && resp.StatusCode < 300
 
@@ -181,6 +240,7 @@ func handleHTTPError(resp *http.Response) error {
 	var message string
 	var parsedBody struct {
 		Message string `json:"message"`
+		Error   string `json:"error"`
 	}
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
@@ -190,9 +250,12 @@ func handleHTTPError(resp *http.Response) error {
 	if err != nil {
 		message = string(body)
 	} else {
-		message = parsedBody.Message
+		message = utils.FirstNonEmpty(parsedBody.Message, parsedBody.Error)
 	}
 
-	// TODO: enhance error type handling
-	return fmt.Errorf("http error, '%s' failed (%d): '%s'", resp.Request.URL, resp.StatusCode, message)
+	return &HTTPError{
+		StatusCode: resp.StatusCode,
+		RequestURL: resp.Request.URL,
+		Message:    message,
+	}
 }
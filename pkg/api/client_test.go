@@ -0,0 +1,55 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type ctxKey struct{}
+
+func TestGraphQLWithContext(t *testing.T) {
+	var got context.Context
+	c := NewClient(ReplaceTripper(&funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+		got = req.Context()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"data":{"ok":true}}`)),
+			Request:    req,
+		}, nil
+	}}))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "pull")
+	var data struct{ OK bool }
+	if err := c.GraphQLWithContext(ctx, "op", "query op { ok }", nil, &data); err != nil {
+		t.Fatal(err)
+	}
+	if !data.OK || got.Value(ctxKey{}) != "pull" {
+		t.Errorf("request context lacks the caller context, data %+v", data)
+	}
+	if got.Value(idempotentKey{}) == nil {
+		t.Error("query not marked idempotent")
+	}
+
+	if err := c.GraphQLWithContext(ctx, "op", "mutation op { ok }", nil, &data); err != nil {
+		t.Fatal(err)
+	}
+	if got.Value(idempotentKey{}) != nil {
+		t.Error("mutation marked idempotent")
+	}
+}
+
+func TestGraphQLWithContextCancelled(t *testing.T) {
+	c := NewClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := c.GraphQLWithContext(ctx, "op", "query op { ok }", nil, &struct{}{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+}
@@ -2,27 +2,39 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
-	"time"
 
+	"github.com/ckidckidckid/leetcode-cli/pkg/diff"
 	"github.com/ckidckidckid/leetcode-cli/pkg/model"
 	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
-	"github.com/kyokomi/emoji"
 )
 
+// MaxInterpretTestCases is the number of test cases leetcode accepts in a
+// single interpretation
+const MaxInterpretTestCases = 10
+
 type interpretInitResp struct {
 	InterpretID string `json:"interpret_id"`
 	TestCase    string `json:"test_case"`
 }
 
-type interpretResp struct {
+// InterpretResult is the judge response of an interpretation
+type InterpretResult struct {
+	DataInput      string   `json:"-"`
+	TestCases      []string `json:"-"`
+	FirstCase      int      `json:"-"`
+	StoredExpected []string `json:"-"`
+	// TypedCode is the code sent to the judge
+	TypedCode              string   `json:"-"`
+	LastTestcase           string   `json:"last_testcase"`
 	State                  string   `json:"state"`
 	CodeAnswer             []string `json:"code_answer"`
 	CodeOutput             []string `json:"code_output"`
+	CompareResult          string   `json:"compare_result"`
+	CompileError           string   `json:"compile_error"`
 	CorrectAnswer          bool     `json:"correct_answer"`
 	ElapsedTime            int      `json:"elapsed_time"`
 	ExpectedCodeAnswer     []string `json:"expected_code_answer"`
@@ -34,6 +46,7 @@ type interpretResp struct {
 	ExpectedStatusCode     int      `json:"expected_status_code"`
 	ExpectedStatusRuntime  string   `json:"expected_status_runtime"`
 	ExpectedTaskFinishTime int      `json:"expected_task_finish_time"`
+	FullCompileError       string   `json:"full_compile_error"`
 	FullRuntimeError       string   `json:"full_runtime_error"`
 	Lang                   string   `json:"lang"`
 	Memory                 int      `json:"memory"`
@@ -46,26 +59,42 @@ type interpretResp struct {
 	StatusMemory           string   `json:"status_memory"`
 	StatusMsg              string   `json:"status_msg"`
 	StatusRuntime          string   `json:"status_runtime"`
+	StdOutput              string   `json:"std_output"`
+	StdOutputList          []string `json:"std_output_list"`
 	SubmissionID           string   `json:"submission_id"`
 	TaskFinishTime         int      `json:"task_finish_time"`
-	TotalCorrect           string   `json:"total_correct"`
-	TotalTestcases         string   `json:"total_testcases"`
+	TotalCorrect           int      `json:"total_correct"`
+	TotalTestcases         int      `json:"total_testcases"`
 }
 
-// InterpretCode with leetcode judge and input testcase
-func (c *Client) InterpretCode(pd *model.ProblemDetail, fp string, dataInput string) error {
-	ext := filepath.Ext(fp)
-	lang, err := pd.GetLanguageSlug(ext)
-	if err != nil {
-		return err
+// CaseResult is the outcome of a single test case of an interpretation
+type CaseResult struct {
+	Input    string
+	Expected string
+	Actual   string
+	Passed   bool
+	// Stored is the expected answer of the test case library, a case whose
+	// answer differs from it does not pass
+	Stored string
+}
+
+// InterpretCode with leetcode judge and input testcases, waiting for the
+// result until ctx is done
+func (c *Client) InterpretCode(ctx context.Context, pd *model.ProblemDetail, fp string, testCases []string) (*InterpretResult, error) {
+	if len(testCases) > MaxInterpretTestCases {
+		return nil, fmt.Errorf("too many test cases: %d, at most %d can be interpreted at once", len(testCases), MaxInterpretTestCases)
 	}
+	dataInput := strings.Join(testCases, "\n")
 
-	file, err := os.ReadFile(fp)
+	lang, err := pd.GetSourceLanguage(fp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	dataInput = strings.ReplaceAll(dataInput, "\\n", "\n")
+	code, err := model.ReadSourceCode(fp, lang)
+	if err != nil {
+		return nil, err
+	}
 
 	url := strings.Replace(utils.InterpretURL, "$slug", pd.TitleSlug, 1)
 
@@ -75,70 +104,112 @@ func (c *Client) InterpretCode(pd *model.ProblemDetail, fp string, dataInput str
 			"judge_type":  "large",
 			"lang":        lang,
 			"question_id": pd.QuestionID,
-			"typed_code":  string(file),
+			"typed_code":  code,
 		},
 	)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	iir := &interpretInitResp{}
-	err = c.REST("POST", url, bytes.NewBuffer(reqBody), iir)
+	err = c.RESTWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody), iir)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for {
-		ir, err := c.verifyInterpretation(iir.InterpretID)
-		if err != nil {
-			return err
-		}
-		switch ir.State {
-		case "PENDING", "STARTED":
-		case "SUCCESS":
-			ir.exportSdtoutInterpretation(dataInput)
-			return nil
-		default:
-			return fmt.Errorf("failure code submission. unexpected submission state: %s", ir.State)
-		}
-		time.Sleep(2 * time.Second)
+	ir, err := c.CheckInterpretation(ctx, iir.InterpretID)
+	if err != nil {
+		return nil, err
 	}
+	ir.DataInput = dataInput
+	ir.TestCases = testCases
+	ir.TypedCode = code
+	return ir, nil
 }
 
-func (c *Client) verifyInterpretation(id string) (*interpretResp, error) {
-	url := strings.Replace(utils.VerifyURL, "$id", id, 1)
-	ir := &interpretResp{}
-	err := c.REST("GET", url, nil, ir)
+// CheckInterpretation waits for the judge result of interpretation id until
+// ctx is done
+func (c *Client) CheckInterpretation(ctx context.Context, id string) (*InterpretResult, error) {
+	ir := &InterpretResult{}
+	err := c.waitForJudge(ctx, id, ir)
 	if err != nil {
 		return nil, err
 	}
 	return ir, nil
 }
 
-func (ir *interpretResp) exportSdtoutInterpretation(t string) {
-	if ir.CorrectAnswer {
-		emoji.Printf("%s :heavy_check_mark:\n\n", utils.Green("Accepted"))
-	} else {
-		emoji.Printf("%s :x:\n\n", utils.Red("Rejected"))
+// Verdict of the interpretation, a successful run whose answer differs from
+// the expected or stored one is a wrong answer
+func (ir *InterpretResult) Verdict() Verdict {
+	v := verdictFromStatus(ir.StatusCode)
+	if v == VerdictAccepted && !ir.CorrectAnswer {
+		return VerdictWrongAnswer
+	}
+	if v == VerdictAccepted {
+		for i := range ir.TestCases {
+			if !ir.matchesStored(i) {
+				return VerdictWrongAnswer
+			}
+		}
+	}
+	return v
+}
+
+// matchesStored reports whether the answer to test case i is the stored
+// expected one, if any
+func (ir *InterpretResult) matchesStored(i int) bool {
+	if i >= len(ir.StoredExpected) || ir.StoredExpected[i] == "" {
+		return true
 	}
+	return i < len(ir.CodeAnswer) && diff.Compare(ir.StoredExpected[i], ir.CodeAnswer[i]).Equal
+}
 
-	fmt.Printf(
-		"%s\n%s",
-		utils.Cyan("Test Case"),
-		fmt.Sprintf("%s\n\n", strings.ReplaceAll(t, "\n", "\\n")),
-	)
+// judgedCorrect reports whether the judge accepted the answer cr to test case
+// i, which may differ from the expected one on problems accepting several
+// answers; without per-case results from the judge the answers must be equal
+func (ir *InterpretResult) judgedCorrect(i int, cr CaseResult) bool {
+	if len(ir.CompareResult) == len(ir.TestCases) {
+		return ir.CompareResult[i] == '1'
+	}
+	return i < len(ir.ExpectedCodeAnswer) && cr.Expected == cr.Actual
+}
 
-	if ir.FullRuntimeError != "" {
-		fmt.Printf("%s\n%s\n", utils.Red("Runtime Error"), utils.Magenta(ir.FullRuntimeError))
-	} else {
-		fmt.Printf("%s\n", utils.Blue("Answer"))
-		fmt.Printf("Expected: %s\n", strings.Join(ir.ExpectedCodeAnswer, ", "))
-		fmt.Printf("Actual:   %s\n\n", strings.Join(ir.CodeAnswer, ", "))
-		fmt.Printf("%s\n", utils.Blue("Runtime"))
-		fmt.Printf("Expected: %s ms\n", ir.ExpectedStatusRuntime)
-		fmt.Printf("Actual:   %s\n\n", ir.StatusRuntime)
-		fmt.Printf("%s\n", utils.Blue("Memory"))
-		fmt.Printf("Expected: %.2f MB\n", float32(ir.ExpectedMemory)/float32(1024)/float32(1024))
-		fmt.Printf("Actual:   %s\n", ir.StatusMemory)
+// CaseResults pairs every test case with its expected, stored and actual
+// answers
+func (ir *InterpretResult) CaseResults() []CaseResult {
+	results := make([]CaseResult, 0, len(ir.TestCases))
+	for i, input := range ir.TestCases {
+		cr := CaseResult{Input: input}
+		if i < len(ir.ExpectedCodeAnswer) {
+			cr.Expected = ir.ExpectedCodeAnswer[i]
+		}
+		if i < len(ir.CodeAnswer) {
+			cr.Actual = ir.CodeAnswer[i]
+		}
+		if i < len(ir.StoredExpected) {
+			cr.Stored = ir.StoredExpected[i]
+		}
+		cr.Passed = ir.Verdict() != VerdictCompileError &&
+			i < len(ir.CodeAnswer) &&
+			ir.judgedCorrect(i, cr) &&
+			ir.matchesStored(i)
+		results = append(results, cr)
+	}
+	return results
+}
+
+// Stdout returns what the code printed, one entry per test case when the
+// judge reports it separately
+func (ir *InterpretResult) Stdout() []string {
+	if len(ir.StdOutputList) > 0 {
+		outputs := ir.StdOutputList
+		if len(ir.TestCases) > 0 && len(outputs) > len(ir.TestCases) {
+			outputs = outputs[:len(ir.TestCases)]
+		}
+		return outputs
+	}
+	if len(ir.CodeOutput) > 0 {
+		return []string{strings.Join(ir.CodeOutput, "\n")}
 	}
+	return nil
 }
@@ -0,0 +1,46 @@
+package api
+
+import "testing"
+
+func TestCaseResultsCompareResult(t *testing.T) {
+	ir := &InterpretResult{
+		TestCases:          []string{"[2,7,11,15]\n9", "[3,2,4]\n6", "[3,3]\n6"},
+		StatusCode:         statusAccepted,
+		CorrectAnswer:      true,
+		CompareResult:      "111",
+		ExpectedCodeAnswer: []string{"[0,1]", "[1,2]", "[0,1]"},
+		// the problem accepts the indices in any order
+		CodeAnswer: []string{"[1,0]", "[1,2]", "[1,0]"},
+	}
+	for i, cr := range ir.CaseResults() {
+		if !cr.Passed {
+			t.Errorf("case %d failed under verdict %s", i+1, ir.Verdict())
+		}
+	}
+
+	ir.CorrectAnswer = false
+	ir.CompareResult = "101"
+	passed := []bool{true, false, true}
+	for i, cr := range ir.CaseResults() {
+		if cr.Passed != passed[i] {
+			t.Errorf("case %d passed = %v, want %v", i+1, cr.Passed, passed[i])
+		}
+	}
+}
+
+func TestCaseResultsWithoutCompareResult(t *testing.T) {
+	ir := &InterpretResult{
+		TestCases:          []string{"[2,7,11,15]\n9", "[3,3]\n6"},
+		StatusCode:         statusAccepted,
+		ExpectedCodeAnswer: []string{"[0,1]", "[0,1]"},
+		CodeAnswer:         []string{"[0,1]", "[1,0]"},
+		StoredExpected:     []string{"[1,0]", ""},
+	}
+	// the stored expected answer fails the first case whatever the judge says
+	passed := []bool{false, false}
+	for i, cr := range ir.CaseResults() {
+		if cr.Passed != passed[i] {
+			t.Errorf("case %d passed = %v, want %v", i+1, cr.Passed, passed[i])
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
@@ -18,7 +19,6 @@ type ProblemDetailCollection struct {
 // GetProblemDetail is the graphql query function fetching leetcode Individual Problem
 func (client *Client) GetProblemDetail(id int, random bool) (*model.ProblemDetail, error) {
 	var titleSlug string
-	var problemDetailCollection ProblemDetailCollection
 
 	if random { // randomly pick problem title slug
 		problemCollection, err := client.GetProblemCollection("all", "", "", "free", "new")
@@ -52,10 +52,50 @@ func (client *Client) GetProblemDetail(id int, random bool) (*model.ProblemDetai
 		}
 	}
 
+	return client.GetProblemDetailBySlug(context.Background(), titleSlug)
+}
+
+// GetProblemDetailBySlug returns the leetcode problem titled slug from the
+// problem cache, or else fetches and caches it until ctx is done
+func (client *Client) GetProblemDetailBySlug(ctx context.Context, titleSlug string) (*model.ProblemDetail, error) {
+	if pd, ok := cachedProblemDetail(titleSlug); ok {
+		return pd, nil
+	}
+
+	pd, err := client.fetchProblemDetail(ctx, titleSlug)
+	if err != nil {
+		return nil, err
+	}
+	// a problem that cannot be cached is only fetched again next time
+	_ = cacheProblemDetail(pd)
+	return pd, nil
+}
+
+// WarmProblemDetail fetches the leetcode problem titled slug into the problem
+// cache unless a fresh copy is cached already or refresh is set, reporting
+// whether it was fetched
+func (client *Client) WarmProblemDetail(ctx context.Context, titleSlug string, refresh bool) (bool, error) {
+	if _, ok := cachedProblemDetail(titleSlug); ok && !refresh {
+		return false, nil
+	}
+
+	pd, err := client.fetchProblemDetail(ctx, titleSlug)
+	if err != nil {
+		return false, err
+	}
+	return true, cacheProblemDetail(pd)
+}
+
+// fetchProblemDetail is the graphql query function fetching the leetcode
+// problem titled slug until ctx is done
+func (client *Client) fetchProblemDetail(ctx context.Context, titleSlug string) (*model.ProblemDetail, error) {
+	var problemDetailCollection ProblemDetailCollection
+
 	variables := make(map[string]interface{})
 	variables["titleSlug"] = titleSlug
 
-	err := client.GraphQL(
+	err := client.GraphQLWithContext(
+		ctx,
 		utils.QuestionDataOperation,
 		utils.QuestionDataQuery,
 		variables,
@@ -64,6 +104,9 @@ func (client *Client) GetProblemDetail(id int, random bool) (*model.ProblemDetai
 	if err != nil {
 		return nil, err
 	}
+	if problemDetailCollection.Question.TitleSlug != titleSlug {
+		return nil, fmt.Errorf("question %s does not exist", titleSlug)
+	}
 
 	return &problemDetailCollection.Question, nil
 }
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy tunes the Retry middleware
+type RetryPolicy struct {
+	// Attempts bounds the tries of a request failing transiently
+	Attempts int
+	// Cooldowns bounds the tries of a run or submission rejected because the
+	// previous one was too recent
+	Cooldowns int
+	// Backoff is the base delay before a retry, doubled on every following
+	// one and jittered, unless the response has a Retry-After
+	Backoff time.Duration
+	// MaxWait caps a single wait, a longer Retry-After ends the retries
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy is the policy of the clients returned by GetAuthClient
+// and GetSubmitClient
+var DefaultRetryPolicy = RetryPolicy{
+	Attempts:  3,
+	Cooldowns: 10,
+	Backoff:   500 * time.Millisecond,
+	MaxWait:   time.Minute,
+}
+
+// cooldownBackoff is the base delay before retrying a run rejected by the
+// judge cooldown without Retry-After
+const cooldownBackoff = 2 * time.Second
+
+// RetryNotice describes a wait before a request is retried
+type RetryNotice struct {
+	// Cooldown is set when the judge asked to wait before running code again
+	Cooldown bool
+	// Reason is what the failed attempt got, e.g. `503 Service Unavailable`
+	Reason  string
+	Wait    time.Duration
+	Attempt int
+}
+
+func (n RetryNotice) String() string {
+	if n.Cooldown {
+		return fmt.Sprintf("leetcode asks to wait before running code again, retrying in %s", n.Wait.Round(100*time.Millisecond))
+	}
+	return fmt.Sprintf("leetcode answered %s, retrying in %s", n.Reason, n.Wait.Round(100*time.Millisecond))
+}
+
+type retryNotifyKey struct{}
+
+type idempotentKey struct{}
+
+// WithRetryNotify returns a context whose requests report their retries to
+// notify before waiting
+func WithRetryNotify(ctx context.Context, notify func(RetryNotice)) context.Context {
+	return context.WithValue(ctx, retryNotifyKey{}, notify)
+}
+
+// withIdempotent marks the requests of ctx as safe to send again whatever
+// their method
+func withIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// Retry turns a RoundTripper into one retrying failed requests with p.
+// Idempotent requests are retried on rate limits, server errors and network
+// failures, other requests only on rate limits since those were not handled.
+// Rejections by the judge cooldown are retried separately, with their own
+// budget, as running code too soon after the previous run is expected.
+func Retry(p RetryPolicy) ClientOption {
+	return func(tr http.RoundTripper) http.RoundTripper {
+		return &funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+			return p.roundTrip(tr, req)
+		}}
+	}
+}
+
+func (p RetryPolicy) roundTrip(tr http.RoundTripper, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	idempotent := ctx.Value(idempotentKey{}) != nil
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		idempotent = true
+	}
+	replayable := req.Body == nil || req.GetBody != nil
+
+	attempts, cooldowns := 0, 0
+	for {
+		attempt := req.Clone(ctx)
+		if req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attempt.Body = body
+		}
+
+		resp, err := tr.RoundTrip(attempt)
+		if !replayable || ctx.Err() != nil {
+			return resp, err
+		}
+
+		notice := RetryNotice{}
+		switch {
+		case err != nil:
+			if !idempotent {
+				return nil, err
+			}
+			notice.Reason = err.Error()
+		case resp.StatusCode == http.StatusTooManyRequests:
+			notice.Cooldown = isCooldown(req, resp)
+			notice.Reason = resp.Status
+		case resp.StatusCode >= http.StatusInternalServerError && idempotent:
+			notice.Reason = resp.Status
+		default:
+			return resp, nil
+		}
+
+		if notice.Cooldown {
+			cooldowns++
+			notice.Attempt = cooldowns
+			notice.Wait = p.delay(resp, cooldownBackoff, cooldowns)
+		} else {
+			attempts++
+			notice.Attempt = attempts
+			notice.Wait = p.delay(resp, p.Backoff, attempts)
+		}
+		if (notice.Cooldown && cooldowns >= p.Cooldowns) || (!notice.Cooldown && attempts >= p.Attempts) || notice.Wait > p.MaxWait {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if notify, ok := ctx.Value(retryNotifyKey{}).(func(RetryNotice)); ok {
+			notify(notice)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(notice.Wait):
+		}
+	}
+}
+
+// delay returns how long to wait before the next try, from the Retry-After
+// of resp or else the jittered exponential backoff from base
+func (p RetryPolicy) delay(resp *http.Response, base time.Duration, attempt int) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			return wait
+		}
+	}
+
+	d := base << (attempt - 1)
+	if p.MaxWait > 0 && d > p.MaxWait {
+		d = p.MaxWait
+	}
+	// full jitter over the upper half keeps concurrent clients apart
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+// retryAfter reads a Retry-After header, either delay seconds or a date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		wait := time.Until(t)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// isCooldown reports whether the 429 resp rejects running or submitting code
+// too soon after the previous run, the body of resp is preserved
+func isCooldown(req *http.Request, resp *http.Response) bool {
+	if strings.HasSuffix(req.URL.Path, "/submit/") || strings.HasSuffix(req.URL.Path, "/interpret_solution/") {
+		return true
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return err == nil && strings.Contains(strings.ToLower(string(body)), "too soon")
+}
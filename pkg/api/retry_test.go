@@ -0,0 +1,174 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testPolicy = RetryPolicy{Attempts: 3, Cooldowns: 2, Backoff: time.Millisecond, MaxWait: time.Second}
+
+// failing answers the first n requests with status and body, then 200
+func failing(n int32, status int, header http.Header, body string) (*httptest.Server, *int32) {
+	var calls int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		if atomic.AddInt32(&calls, 1) <= n {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			io.WriteString(w, body)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	return s, &calls
+}
+
+func send(t *testing.T, ctx context.Context, p RetryPolicy, method string, url string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, method, url, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := Retry(p)(http.DefaultTransport).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestRetryServerErrors(t *testing.T) {
+	s, calls := failing(2, http.StatusServiceUnavailable, nil, "")
+	defer s.Close()
+
+	var notices []RetryNotice
+	ctx := WithRetryNotify(context.Background(), func(n RetryNotice) { notices = append(notices, n) })
+	resp := send(t, ctx, testPolicy, http.MethodGet, s.URL)
+	if resp.StatusCode != http.StatusOK || *calls != 3 {
+		t.Errorf("got %d after %d calls, want 200 after 3", resp.StatusCode, *calls)
+	}
+	if len(notices) != 2 || notices[1].Attempt != 2 || notices[0].Reason != "503 Service Unavailable" {
+		t.Errorf("notices = %+v, want two server error retries", notices)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	s, calls := failing(10, http.StatusBadGateway, nil, "")
+	defer s.Close()
+
+	resp := send(t, context.Background(), testPolicy, http.MethodGet, s.URL)
+	if resp.StatusCode != http.StatusBadGateway || *calls != 3 {
+		t.Errorf("got %d after %d calls, want 502 after 3", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	s, calls := failing(1, http.StatusInternalServerError, nil, "")
+	defer s.Close()
+
+	resp := send(t, context.Background(), testPolicy, http.MethodPost, s.URL)
+	if resp.StatusCode != http.StatusInternalServerError || *calls != 1 {
+		t.Errorf("got %d after %d calls, want the 500 without retry", resp.StatusCode, *calls)
+	}
+
+	s2, calls := failing(1, http.StatusInternalServerError, nil, "")
+	defer s2.Close()
+	resp = send(t, withIdempotent(context.Background()), testPolicy, http.MethodPost, s2.URL)
+	if resp.StatusCode != http.StatusOK || *calls != 2 {
+		t.Errorf("got %d after %d calls, want an idempotent POST retried", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryRateLimited(t *testing.T) {
+	s, calls := failing(2, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, "")
+	defer s.Close()
+
+	resp := send(t, context.Background(), testPolicy, http.MethodPost, s.URL)
+	if resp.StatusCode != http.StatusOK || *calls != 3 {
+		t.Errorf("got %d after %d calls, want a rate limited POST retried", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryCooldown(t *testing.T) {
+	body := `{"error":"You have attempted to run code too soon."}`
+	s, calls := failing(4, http.StatusTooManyRequests, http.Header{"Retry-After": {"0"}}, body)
+	defer s.Close()
+
+	var notices []RetryNotice
+	ctx := WithRetryNotify(context.Background(), func(n RetryNotice) { notices = append(notices, n) })
+	p := testPolicy
+	p.Cooldowns = 5
+	resp := send(t, ctx, p, http.MethodPost, s.URL)
+	if resp.StatusCode != http.StatusOK || *calls != 5 {
+		t.Errorf("got %d after %d calls, want 200 after 5 past the attempts budget", resp.StatusCode, *calls)
+	}
+	for _, n := range notices {
+		if !n.Cooldown {
+			t.Errorf("notice %+v is not a cooldown", n)
+		}
+	}
+}
+
+func TestRetryMaxWait(t *testing.T) {
+	s, calls := failing(1, http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}}, "")
+	defer s.Close()
+
+	resp := send(t, context.Background(), testPolicy, http.MethodGet, s.URL)
+	if resp.StatusCode != http.StatusTooManyRequests || *calls != 1 {
+		t.Errorf("got %d after %d calls, want the 429 as Retry-After exceeds MaxWait", resp.StatusCode, *calls)
+	}
+}
+
+func TestRetryContextDone(t *testing.T) {
+	s, _ := failing(10, http.StatusServiceUnavailable, http.Header{"Retry-After": {"1"}}, "")
+	defer s.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	_, err := Retry(testPolicy)(http.DefaultTransport).RoundTrip(req)
+	if err != context.DeadlineExceeded {
+		t.Errorf("got error %v, want the context deadline", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		wait  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"3", 3 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		wait, ok := retryAfter(tt.value)
+		if wait != tt.wait || ok != tt.ok {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", tt.value, wait, ok, tt.wait, tt.ok)
+		}
+	}
+}
+
+func TestDelay(t *testing.T) {
+	p := RetryPolicy{Backoff: 100 * time.Millisecond, MaxWait: 300 * time.Millisecond}
+	for attempt, max := range []time.Duration{100, 200, 300, 300} {
+		max *= time.Millisecond
+		for i := 0; i < 20; i++ {
+			d := p.delay(nil, p.Backoff, attempt+1)
+			if d < max/2 || d > max {
+				t.Fatalf("delay of attempt %d = %v, want within [%v, %v]", attempt+1, d, max/2, max)
+			}
+		}
+	}
+}
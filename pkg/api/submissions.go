@@ -0,0 +1,257 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+// submissionPageSize is the number of submissions fetched per request
+const submissionPageSize = 20
+
+// SubmissionSummary is a past submission as listed by questionSubmissionList
+type SubmissionSummary struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"`
+	TitleSlug     string `json:"titleSlug"`
+	Status        int    `json:"status"`
+	StatusDisplay string `json:"statusDisplay"`
+	Lang          string `json:"lang"`
+	LangName      string `json:"langName"`
+	Runtime       string `json:"runtime"`
+	Memory        string `json:"memory"`
+	Timestamp     string `json:"timestamp"`
+	IsPending     string `json:"isPending"`
+}
+
+type submissionListCollection struct {
+	QuestionSubmissionList struct {
+		LastKey     *string             `json:"lastKey"`
+		HasNext     bool                `json:"hasNext"`
+		Submissions []SubmissionSummary `json:"submissions"`
+	} `json:"questionSubmissionList"`
+}
+
+// Verdict maps the status of the submission to its verdict
+func (s *SubmissionSummary) Verdict() Verdict {
+	return verdictFromStatus(s.Status)
+}
+
+// Time is when the submission was made
+func (s *SubmissionSummary) Time() time.Time {
+	return parseTimestamp(s.Timestamp)
+}
+
+// GetSubmissions is the graphql query function listing the submissions of
+// the signed-in user to problem slug, latest first, at most limit of them
+// unless limit is 0, until ctx is done
+func (client *Client) GetSubmissions(ctx context.Context, slug string, limit int) ([]SubmissionSummary, error) {
+	var submissions []SubmissionSummary
+	err := client.eachSubmission(ctx, slug, func(s SubmissionSummary) bool {
+		submissions = append(submissions, s)
+		return limit == 0 || len(submissions) < limit
+	})
+	if err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+// FindSubmission returns the latest submission of the signed-in user to
+// problem slug satisfying match, or nil, fetching no more pages than needed
+func (client *Client) FindSubmission(ctx context.Context, slug string, match func(SubmissionSummary) bool) (*SubmissionSummary, error) {
+	var found *SubmissionSummary
+	err := client.eachSubmission(ctx, slug, func(s SubmissionSummary) bool {
+		if match(s) {
+			found = &s
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// eachSubmission pages through the submissions to problem slug, latest
+// first, calling fn on each of them until it returns false
+func (client *Client) eachSubmission(ctx context.Context, slug string, fn func(SubmissionSummary) bool) error {
+	var lastKey *string
+	offset := 0
+
+	for {
+		var collection submissionListCollection
+		err := client.GraphQLWithContext(
+			ctx,
+			utils.QuestionSubmissionListOperation,
+			utils.QuestionSubmissionListQuery,
+			map[string]interface{}{
+				"questionSlug": slug,
+				"offset":       offset,
+				"limit":        submissionPageSize,
+				"lastKey":      lastKey,
+			},
+			&collection,
+		)
+		if err != nil {
+			return err
+		}
+
+		list := collection.QuestionSubmissionList
+		for _, s := range list.Submissions {
+			if !fn(s) {
+				return nil
+			}
+		}
+		offset += len(list.Submissions)
+		if !list.HasNext || len(list.Submissions) == 0 {
+			return nil
+		}
+		lastKey = list.LastKey
+	}
+}
+
+// SubmissionDetail is a past submission with its code as returned by
+// submissionDetails
+type SubmissionDetail struct {
+	ID                  string  `json:"-"`
+	Runtime             int     `json:"runtime"`
+	RuntimeDisplay      string  `json:"runtimeDisplay"`
+	RuntimePercentile   float32 `json:"runtimePercentile"`
+	RuntimeDistribution string  `json:"runtimeDistribution"`
+	Memory              int     `json:"memory"`
+	MemoryDisplay       string  `json:"memoryDisplay"`
+	MemoryPercentile    float32 `json:"memoryPercentile"`
+	Code                string  `json:"code"`
+	Timestamp           int64   `json:"timestamp"`
+	StatusCode          int     `json:"statusCode"`
+	Lang                struct {
+		Name        string `json:"name"`
+		VerboseName string `json:"verboseName"`
+	} `json:"lang"`
+	Question struct {
+		QuestionID         string `json:"questionId"`
+		QuestionFrontendID string `json:"questionFrontendId"`
+		TitleSlug          string `json:"titleSlug"`
+	} `json:"question"`
+	RuntimeError   string `json:"runtimeError"`
+	CompileError   string `json:"compileError"`
+	LastTestcase   string `json:"lastTestcase"`
+	CodeOutput     string `json:"codeOutput"`
+	ExpectedOutput string `json:"expectedOutput"`
+	TotalCorrect   int    `json:"totalCorrect"`
+	TotalTestcases int    `json:"totalTestcases"`
+	StdOutput      string `json:"stdOutput"`
+}
+
+type submissionDetailCollection struct {
+	SubmissionDetails *SubmissionDetail `json:"submissionDetails"`
+}
+
+// DistributionBucket is the share of accepted submissions at a runtime
+type DistributionBucket struct {
+	Value   string
+	Percent float64
+}
+
+// GetSubmissionDetail is the graphql query function fetching a past
+// submission of the signed-in user along with its code until ctx is done
+func (client *Client) GetSubmissionDetail(ctx context.Context, id string) (*SubmissionDetail, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid submission ID %q", id)
+	}
+
+	var collection submissionDetailCollection
+	err = client.GraphQLWithContext(
+		ctx,
+		utils.SubmissionDetailsOperation,
+		utils.SubmissionDetailsQuery,
+		map[string]interface{}{"submissionId": n},
+		&collection,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if collection.SubmissionDetails == nil {
+		return nil, fmt.Errorf("submission %s does not exist or is not yours", id)
+	}
+
+	collection.SubmissionDetails.ID = id
+	return collection.SubmissionDetails, nil
+}
+
+// Verdict maps the status of the submission to its verdict
+func (d *SubmissionDetail) Verdict() Verdict {
+	return verdictFromStatus(d.StatusCode)
+}
+
+// Time is when the submission was made
+func (d *SubmissionDetail) Time() time.Time {
+	return time.Unix(d.Timestamp, 0)
+}
+
+// Result converts the detail into the judge response of the submission, so
+// that it is rendered like a fresh one
+func (d *SubmissionDetail) Result() *SubmissionResult {
+	return &SubmissionResult{
+		State:             "SUCCESS",
+		CodeOutput:        d.CodeOutput,
+		CompileError:      d.CompileError,
+		ExpectedOutput:    d.ExpectedOutput,
+		FullCompileError:  d.CompileError,
+		FullRuntimeError:  d.RuntimeError,
+		Lang:              d.Lang.Name,
+		LastTestcase:      d.LastTestcase,
+		Memory:            d.Memory,
+		MemoryPercentile:  d.MemoryPercentile,
+		PrettyLang:        d.Lang.VerboseName,
+		QuestionID:        d.Question.QuestionID,
+		RunSuccess:        d.CompileError == "",
+		RuntimeError:      d.RuntimeError,
+		RuntimePercentile: d.RuntimePercentile,
+		StatusCode:        d.StatusCode,
+		StatusMemory:      d.MemoryDisplay,
+		StatusMsg:         d.Verdict().String(),
+		StatusRuntime:     d.RuntimeDisplay,
+		StdOutput:         d.StdOutput,
+		SubmissionID:      d.ID,
+		TotalCorrect:      d.TotalCorrect,
+		TotalTestcases:    d.TotalTestcases,
+	}
+}
+
+// RuntimeBuckets decodes the runtime distribution of accepted submissions in
+// the language of the submission, empty unless it was accepted
+func (d *SubmissionDetail) RuntimeBuckets() ([]DistributionBucket, error) {
+	if d.RuntimeDistribution == "" {
+		return nil, nil
+	}
+
+	var distribution struct {
+		Distribution [][2]interface{} `json:"distribution"`
+	}
+	err := json.Unmarshal([]byte(d.RuntimeDistribution), &distribution)
+	if err != nil {
+		return nil, fmt.Errorf("invalid runtime distribution: %v", err)
+	}
+
+	buckets := make([]DistributionBucket, 0, len(distribution.Distribution))
+	for _, pair := range distribution.Distribution {
+		value := fmt.Sprint(pair[0])
+		percent, _ := pair[1].(float64)
+		buckets = append(buckets, DistributionBucket{Value: value, Percent: percent})
+	}
+	return buckets, nil
+}
+
+// parseTimestamp reads a unix timestamp in seconds
+func parseTimestamp(s string) time.Time {
+	sec, _ := strconv.ParseInt(s, 10, 64)
+	return time.Unix(sec, 0)
+}
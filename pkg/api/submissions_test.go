@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// submissionPages serves n submissions, those at the accepted indices being
+// accepted, counting the pages requested
+func submissionPages(t *testing.T, n int, accepted ...int) (*Client, *int) {
+	statuses := make([]int, n)
+	for i := range statuses {
+		statuses[i] = 11
+	}
+	for _, i := range accepted {
+		statuses[i] = 10
+	}
+
+	pages := 0
+	c := NewClient(ReplaceTripper(&funcTripper{roundTrip: func(req *http.Request) (*http.Response, error) {
+		var body struct {
+			Variables struct{ Offset, Limit int }
+		}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		pages++
+
+		var list submissionListCollection
+		for i := body.Variables.Offset; i < n && i < body.Variables.Offset+body.Variables.Limit; i++ {
+			list.QuestionSubmissionList.Submissions = append(list.QuestionSubmissionList.Submissions, SubmissionSummary{
+				ID:     strconv.Itoa(i),
+				Status: statuses[i],
+			})
+		}
+		list.QuestionSubmissionList.HasNext = body.Variables.Offset+body.Variables.Limit < n
+		b, _ := json.Marshal(map[string]interface{}{"data": list})
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(bytes.NewReader(b)),
+			Request:    req,
+		}, nil
+	}}))
+	return c, &pages
+}
+
+func isAccepted(s SubmissionSummary) bool {
+	return s.Verdict() == VerdictAccepted
+}
+
+func TestFindSubmission(t *testing.T) {
+	c, pages := submissionPages(t, 100, 25, 30)
+	s, err := c.FindSubmission(context.Background(), "two-sum", isAccepted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == nil || s.ID != "25" {
+		t.Fatalf("found %+v, want submission 25", s)
+	}
+	if *pages != 2 {
+		t.Errorf("fetched %d pages, want 2", *pages)
+	}
+
+	c, pages = submissionPages(t, 45)
+	s, err = c.FindSubmission(context.Background(), "two-sum", isAccepted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil || *pages != 3 {
+		t.Errorf("found %+v in %d pages, want nothing after 3", s, *pages)
+	}
+}
+
+func TestGetSubmissions(t *testing.T) {
+	c, pages := submissionPages(t, 45)
+	list, err := c.GetSubmissions(context.Background(), "two-sum", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 45 || list[44].ID != "44" || *pages != 3 {
+		t.Errorf("listed %d submissions in %d pages, want 45 in 3", len(list), *pages)
+	}
+
+	c, pages = submissionPages(t, 45)
+	list, err = c.GetSubmissions(context.Background(), "two-sum", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 5 || *pages != 1 {
+		t.Errorf("listed %d submissions in %d pages, want 5 in 1", len(list), *pages)
+	}
+}
@@ -2,28 +2,28 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"strings"
-	"time"
 
 	"github.com/ckidckidckid/leetcode-cli/pkg/model"
 	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
-	"github.com/kyokomi/emoji"
 )
 
 type submitInitResp struct {
 	SubmissionID int `json:"submission_id"`
 }
 
-type submitResp struct {
+// SubmissionResult is the judge response of a submission
+type SubmissionResult struct {
 	State             string  `json:"state"`
 	CodeOutput        string  `json:"code_output"`
 	CompareResult     string  `json:"compare_result"`
+	CompileError      string  `json:"compile_error"`
 	ElapsedTime       int     `json:"elapsed_time"`
 	ExpectedOutput    string  `json:"expected_output"`
+	FullCompileError  string  `json:"full_compile_error"`
 	FullRuntimeError  string  `json:"full_runtime_error"`
 	Lang              string  `json:"lang"`
 	LastTestcase      string  `json:"last_testcase"`
@@ -35,6 +35,7 @@ type submitResp struct {
 	RuntimeError      string  `json:"runtime_error"`
 	RuntimePercentile float32 `json:"runtime_percentile"`
 	StatusCode        int     `json:"status_code"`
+	StatusDisplay     string  `json:"status_display"`
 	StatusMemory      string  `json:"status_memory"`
 	StatusMsg         string  `json:"status_msg"`
 	StatusRuntime     string  `json:"status_runtime"`
@@ -45,89 +46,77 @@ type submitResp struct {
 	TotalTestcases    int     `json:"total_testcases"`
 }
 
-// SubmitCode to leetcode judge
-func (c *Client) SubmitCode(pd *model.ProblemDetail, fp string) error {
-	ext := filepath.Ext(fp)
-	lang, err := pd.GetLanguageSlug(ext)
+// SubmitPayload is the request body of a submission
+type SubmitPayload struct {
+	Lang       string `json:"lang"`
+	QuestionID string `json:"question_id"`
+	TypedCode  string `json:"typed_code"`
+}
+
+// NewSubmitPayload builds the submission request body of the source file fp,
+// running its transform pipeline
+func NewSubmitPayload(pd *model.ProblemDetail, fp string) (*SubmitPayload, error) {
+	lang, err := pd.GetSourceLanguage(fp)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	file, err := os.ReadFile(fp)
+	code, err := model.ReadSourceCode(fp, lang)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	url := strings.Replace(utils.SubmitURL, "$slug", pd.TitleSlug, 1)
+	return &SubmitPayload{Lang: lang, QuestionID: pd.QuestionID, TypedCode: code}, nil
+}
 
-	reqBody, err := json.Marshal(
-		map[string]interface{}{
-			"lang":        lang,
-			"question_id": pd.QuestionID,
-			"typed_code":  string(file),
-		},
-	)
+// SubmitCode to leetcode judge, waiting for the verdict until ctx is done
+func (c *Client) SubmitCode(ctx context.Context, pd *model.ProblemDetail, fp string) (*SubmissionResult, error) {
+	payload, err := NewSubmitPayload(pd, fp)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return c.Submit(ctx, pd, payload)
+}
 
-	sr := &submitInitResp{}
-	err = c.REST("POST", url, bytes.NewBuffer(reqBody), sr)
+// Submit posts payload to leetcode judge, waiting for the verdict until ctx is
+// done
+func (c *Client) Submit(ctx context.Context, pd *model.ProblemDetail, payload *SubmitPayload) (*SubmissionResult, error) {
+	id, err := c.PostSubmission(ctx, pd, payload)
+	if err != nil {
+		return nil, err
+	}
+	return c.CheckSubmission(ctx, id)
+}
+
+// PostSubmission posts payload to leetcode judge without waiting for the
+// verdict, returning the ID of the submission
+func (c *Client) PostSubmission(ctx context.Context, pd *model.ProblemDetail, payload *SubmitPayload) (string, error) {
+	url := strings.Replace(utils.SubmitURL, "$slug", pd.TitleSlug, 1)
+
+	reqBody, err := json.Marshal(payload)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	for {
-		vr, err := c.verifySubmission(sr.SubmissionID)
-		if err != nil {
-			return err
-		}
-		switch vr.State {
-		case "PENDING", "STARTED":
-		case "SUCCESS":
-			vr.exportSdtoutSubmission()
-			return nil
-		default:
-			return fmt.Errorf("failure code submission. unexpected submission state: %s", vr.State)
-		}
-		time.Sleep(2 * time.Second)
+	sr := &submitInitResp{}
+	err = c.RESTWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody), sr)
+	if err != nil {
+		return "", err
 	}
+	return fmt.Sprintf("%d", sr.SubmissionID), nil
 }
 
-func (c *Client) verifySubmission(id int) (*submitResp, error) {
-	idstr := fmt.Sprintf("%d", id)
-	url := strings.Replace(utils.VerifyURL, "$id", idstr, 1)
-	vr := &submitResp{}
-	err := c.REST("GET", url, nil, vr)
+// CheckSubmission waits for the judge verdict of submission id until ctx is done
+func (c *Client) CheckSubmission(ctx context.Context, id string) (*SubmissionResult, error) {
+	vr := &SubmissionResult{}
+	err := c.waitForJudge(ctx, id, vr)
 	if err != nil {
 		return nil, err
 	}
 	return vr, nil
 }
 
-func (vr *submitResp) exportSdtoutSubmission() {
-	if vr.StatusMsg == "Accepted" {
-		emoji.Printf("%s :heavy_check_mark:\n", utils.Green("Accepted"))
-		fmt.Printf("%d/%d test cases passed\n\n", vr.TotalCorrect, vr.TotalTestcases)
-		fmt.Printf("%s\n", utils.Blue("Runtime"))
-		fmt.Printf("%s, faster than %.2f%% submissions\n\n", vr.StatusRuntime, vr.RuntimePercentile)
-		fmt.Printf("%s\n", utils.Blue("Memory"))
-		fmt.Printf("%s, less than %.2f%% submissions\n", vr.StatusMemory, vr.MemoryPercentile)
-	} else {
-		emoji.Printf("%s :x:\n", utils.Red("Rejected"))
-		fmt.Printf("%d/%d test cases passed\n\n", vr.TotalCorrect, vr.TotalTestcases)
-		fmt.Printf(
-			"%s\n%s",
-			utils.Cyan("Last Test Case"),
-			fmt.Sprintf("%s\n\n", strings.ReplaceAll(vr.LastTestcase, "\n", "\\n")),
-		)
-
-		if vr.FullRuntimeError != "" {
-			fmt.Printf("%s\n%s\n", utils.Red("Runtime Error"), utils.Magenta(vr.FullRuntimeError))
-		} else {
-			fmt.Printf("%s\n", utils.Red("Wrong Answer"))
-			fmt.Printf("Expected   %s\n", vr.ExpectedOutput)
-			fmt.Printf("Actual     %s\n", vr.CodeOutput)
-		}
-	}
+// Verdict of the submission
+func (vr *SubmissionResult) Verdict() Verdict {
+	return verdictFromStatus(vr.StatusCode)
 }
@@ -0,0 +1,174 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Verdict is the judge outcome of a submission or an interpretation
+type Verdict int
+
+// Verdicts reported by the leetcode judge
+const (
+	VerdictUnknown Verdict = iota
+	VerdictAccepted
+	VerdictWrongAnswer
+	VerdictCompileError
+	VerdictRuntimeError
+	VerdictTimeLimitExceeded
+	VerdictMemoryLimitExceeded
+	VerdictOutputLimitExceeded
+	VerdictInternalError
+)
+
+// Judge status codes returned by the check endpoint
+const (
+	statusAccepted            = 10
+	statusWrongAnswer         = 11
+	statusMemoryLimitExceeded = 12
+	statusOutputLimitExceeded = 13
+	statusTimeLimitExceeded   = 14
+	statusRuntimeError        = 15
+	statusInternalError       = 16
+	statusCompileError        = 20
+	statusUnknownError        = 21
+	statusTimeout             = 30
+)
+
+var runtimeErrorLineRegexp = regexp.MustCompile(`(?i)\bline (\d+)`)
+
+func (v Verdict) String() string {
+	switch v {
+	case VerdictAccepted:
+		return "Accepted"
+	case VerdictWrongAnswer:
+		return "Wrong Answer"
+	case VerdictCompileError:
+		return "Compile Error"
+	case VerdictRuntimeError:
+		return "Runtime Error"
+	case VerdictTimeLimitExceeded:
+		return "Time Limit Exceeded"
+	case VerdictMemoryLimitExceeded:
+		return "Memory Limit Exceeded"
+	case VerdictOutputLimitExceeded:
+		return "Output Limit Exceeded"
+	case VerdictInternalError:
+		return "Internal Error"
+	default:
+		return "Unknown"
+	}
+}
+
+// Slug is the stable machine readable name of the verdict
+func (v Verdict) Slug() string {
+	switch v {
+	case VerdictAccepted:
+		return "accepted"
+	case VerdictWrongAnswer:
+		return "wrong_answer"
+	case VerdictCompileError:
+		return "compile_error"
+	case VerdictRuntimeError:
+		return "runtime_error"
+	case VerdictTimeLimitExceeded:
+		return "time_limit_exceeded"
+	case VerdictMemoryLimitExceeded:
+		return "memory_limit_exceeded"
+	case VerdictOutputLimitExceeded:
+		return "output_limit_exceeded"
+	case VerdictInternalError:
+		return "internal_error"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the verdict as its slug
+func (v Verdict) MarshalText() ([]byte, error) {
+	return []byte(v.Slug()), nil
+}
+
+// UnmarshalText decodes a verdict from its slug, unknown slugs yield
+// VerdictUnknown
+func (v *Verdict) UnmarshalText(text []byte) error {
+	*v = VerdictUnknown
+	for c := VerdictAccepted; c <= VerdictInternalError; c++ {
+		if c.Slug() == string(text) {
+			*v = c
+		}
+	}
+	return nil
+}
+
+// Abbrev is the usual short name of the verdict, like `wa` or `tle`
+func (v Verdict) Abbrev() string {
+	switch v {
+	case VerdictAccepted:
+		return "ac"
+	case VerdictWrongAnswer:
+		return "wa"
+	case VerdictCompileError:
+		return "ce"
+	case VerdictRuntimeError:
+		return "re"
+	case VerdictTimeLimitExceeded:
+		return "tle"
+	case VerdictMemoryLimitExceeded:
+		return "mle"
+	case VerdictOutputLimitExceeded:
+		return "ole"
+	case VerdictInternalError:
+		return "ie"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseVerdict reads a verdict from its slug, name or abbreviation, ignoring
+// case
+func ParseVerdict(s string) (Verdict, error) {
+	for v := VerdictAccepted; v <= VerdictInternalError; v++ {
+		if strings.EqualFold(s, v.Slug()) || strings.EqualFold(s, v.String()) || strings.EqualFold(s, v.Abbrev()) {
+			return v, nil
+		}
+	}
+	return VerdictUnknown, fmt.Errorf("unknown verdict %q", s)
+}
+
+// verdictFromStatus maps a judge status code to its verdict
+func verdictFromStatus(code int) Verdict {
+	switch code {
+	case statusAccepted:
+		return VerdictAccepted
+	case statusWrongAnswer:
+		return VerdictWrongAnswer
+	case statusCompileError:
+		return VerdictCompileError
+	case statusRuntimeError:
+		return VerdictRuntimeError
+	case statusTimeLimitExceeded, statusTimeout:
+		return VerdictTimeLimitExceeded
+	case statusMemoryLimitExceeded:
+		return VerdictMemoryLimitExceeded
+	case statusOutputLimitExceeded:
+		return VerdictOutputLimitExceeded
+	case statusInternalError, statusUnknownError:
+		return VerdictInternalError
+	default:
+		return VerdictUnknown
+	}
+}
+
+// ErrorLine extracts the source line a runtime or compile error points at,
+// returning 0 when the judge message carries no location
+func ErrorLine(message string) int {
+	m := runtimeErrorLineRegexp.FindStringSubmatch(message)
+	if m == nil {
+		return 0
+	}
+	line, _ := strconv.Atoi(m[1])
+	return line
+}
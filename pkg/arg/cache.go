@@ -0,0 +1,28 @@
+package arg
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// CacheWarm cmd argument checking
+func CacheWarm(cmd *cobra.Command, args []string) error {
+	err := checkIDOrAll(cmd)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.Flags().GetBool("refresh")
+	if err != nil {
+		return err
+	}
+
+	return checkBulk(cmd)
+}
+
+// CacheClear cmd argument checking
+func CacheClear(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return flagErrorf("invalid arguments: cache clear takes no argument")
+	}
+	return nil
+}
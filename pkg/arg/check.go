@@ -0,0 +1,24 @@
+package arg
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Check cmd argument checking
+func Check(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 || args[0] == "" {
+		return flagErrorf("missing required argument: 'submission-id'")
+	}
+
+	_, err := cmd.Flags().GetDuration("timeout")
+	if err != nil {
+		return err
+	}
+
+	err = checkStdout(cmd)
+	if err != nil {
+		return err
+	}
+
+	return checkOutput(cmd)
+}
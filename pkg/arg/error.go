@@ -0,0 +1,121 @@
+package arg
+
+import (
+	"fmt"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// FlagError is returned when a command is invoked with invalid arguments
+type FlagError struct {
+	Err error
+}
+
+func (fe *FlagError) Error() string {
+	return fe.Err.Error()
+}
+
+func (fe *FlagError) Unwrap() error {
+	return fe.Err
+}
+
+func flagErrorf(format string, a ...interface{}) error {
+	return &FlagError{Err: fmt.Errorf(format, a...)}
+}
+
+// checkOutput validates the `output` flag shared by commands printing results
+func checkOutput(cmd *cobra.Command) error {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+	if !utils.Contains([]interface{}{"text", "json"}, output) {
+		return flagErrorf("invalid arguments: %s = %s", "output", output)
+	}
+	return nil
+}
+
+// checkStdout validates the `stdout-limit` flag shared by commands printing
+// results
+func checkStdout(cmd *cobra.Command) error {
+	limit, err := cmd.Flags().GetInt("stdout-limit")
+	if err != nil {
+		return err
+	}
+	if limit < 0 {
+		return flagErrorf("invalid arguments: %s = %d", "stdout-limit", limit)
+	}
+	return nil
+}
+
+// checkSource validates the solution file given by the `file` flag or a single
+// argument, along with the optional `id` flag
+func checkSource(cmd *cobra.Command, args []string) error {
+	id, err := cmd.Flags().GetInt("id")
+	if err != nil {
+		return err
+	}
+	if id < 0 {
+		return flagErrorf("invalid arguments: %s = %d", "id", id)
+	}
+
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	if len(args) > 1 {
+		return flagErrorf("invalid arguments: at most one solution file should be given")
+	}
+	if file != "" && len(args) == 1 {
+		return flagErrorf("invalid arguments: only one of 'file', file argument should be applied")
+	}
+	if file == "" && len(args) == 0 {
+		return flagErrorf("missing required parameter: 'file'")
+	}
+	return nil
+}
+
+// checkIDOrAll validates the `id` and `all` flags of bulk commands, selecting
+// either one problem or all of them
+func checkIDOrAll(cmd *cobra.Command) error {
+	id, err := cmd.Flags().GetInt("id")
+	if err != nil {
+		return err
+	}
+	if id < 0 {
+		return flagErrorf("invalid arguments: %s = %d", "id", id)
+	}
+
+	all, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+	if id == 0 && !all {
+		return flagErrorf("missing required parameter: either 'id', 'all' should be applied")
+	}
+	if id != 0 && all {
+		return flagErrorf("invalid arguments: only one of 'id', 'all' should be applied")
+	}
+	return nil
+}
+
+// checkBulk validates the `workers` and `rate` flags of bulk commands
+func checkBulk(cmd *cobra.Command) error {
+	workers, err := cmd.Flags().GetInt("workers")
+	if err != nil {
+		return err
+	}
+	if workers < 1 {
+		return flagErrorf("invalid arguments: %s = %d", "workers", workers)
+	}
+
+	rate, err := cmd.Flags().GetFloat64("rate")
+	if err != nil {
+		return err
+	}
+	if rate < 0 {
+		return flagErrorf("invalid arguments: %s = %g", "rate", rate)
+	}
+	return nil
+}
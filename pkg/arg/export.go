@@ -0,0 +1,20 @@
+package arg
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Export cmd argument checking
+func Export(cmd *cobra.Command, args []string) error {
+	err := checkIDOrAll(cmd)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.Flags().GetString("lang")
+	if err != nil {
+		return err
+	}
+
+	return checkBulk(cmd)
+}
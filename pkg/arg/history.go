@@ -0,0 +1,59 @@
+package arg
+
+import (
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/journal"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// History cmd argument checking
+func History(cmd *cobra.Command, args []string) error {
+	id, err := cmd.Flags().GetInt("id")
+	if err != nil {
+		return err
+	}
+	if id < 0 {
+		return flagErrorf("invalid arguments: %s = %d", "id", id)
+	}
+
+	since, err := cmd.Flags().GetString("since")
+	if err != nil {
+		return err
+	}
+	if since != "" {
+		if _, err := journal.ParseSince(since, time.Now()); err != nil {
+			return &FlagError{Err: err}
+		}
+	}
+
+	verdict, err := cmd.Flags().GetString("verdict")
+	if err != nil {
+		return err
+	}
+	if verdict != "" {
+		if _, err := api.ParseVerdict(verdict); err != nil {
+			return &FlagError{Err: err}
+		}
+	}
+
+	kind, err := cmd.Flags().GetString("kind")
+	if err != nil {
+		return err
+	}
+	if !utils.Contains([]interface{}{"all", "submission", "interpretation"}, kind) {
+		return flagErrorf("invalid arguments: %s = %s", "kind", kind)
+	}
+
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return err
+	}
+	if limit < 0 {
+		return flagErrorf("invalid arguments: %s = %d", "limit", limit)
+	}
+
+	return checkOutput(cmd)
+}
@@ -1,36 +1,32 @@
 package arg
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
 // Interpret cmd argument checking
 func Interpret(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetInt("id")
+	err := checkSource(cmd, args)
 	if err != nil {
 		return err
 	}
-	if id == 0 {
-		return fmt.Errorf("missing required parameter: 'id'")
-	}
 
-	file, err := cmd.Flags().GetString("file")
+	testInput, err := cmd.Flags().GetString("test_input")
+	if err != nil {
+		return err
+	}
+	testFile, err := cmd.Flags().GetString("test_file")
 	if err != nil {
 		return err
 	}
-	if file == "" {
-		return fmt.Errorf("missing required parameter: 'file'")
+	if testInput != "" && testFile != "" {
+		return flagErrorf("invalid arguments: only one of 'test_input', 'test_file' should be applied")
 	}
 
-	testInput, err := cmd.Flags().GetString("test_input")
+	err = checkStdout(cmd)
 	if err != nil {
 		return err
 	}
-	if testInput == "" {
-		return fmt.Errorf("missing required parameter: 'test_input'")
-	}
 
-	return nil
+	return checkOutput(cmd)
 }
@@ -1,8 +1,6 @@
 package arg
 
 import (
-	"fmt"
-
 	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
 	"github.com/spf13/cobra"
 )
@@ -14,7 +12,7 @@ func List(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if !utils.Contains([]interface{}{"all", "algorithms", "database", "shell"}, category) {
-		return fmt.Errorf("invalid arguments: %s = %s", "category", category)
+		return flagErrorf("invalid arguments: %s = %s", "category", category)
 	}
 
 	_, err = cmd.Flags().GetString("name")
@@ -32,7 +30,7 @@ func List(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if !utils.Contains([]interface{}{"all", "free", "locked"}, lock) {
-		return fmt.Errorf("invalid arguments: %s = %s", "lock", lock)
+		return flagErrorf("invalid arguments: %s = %s", "lock", lock)
 	}
 
 	status, err := cmd.Flags().GetString("status")
@@ -40,7 +38,7 @@ func List(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	if !utils.Contains([]interface{}{"all", "approved", "rejected", "new"}, status) {
-		return fmt.Errorf("invalid arguments: %s = %s", "status", status)
+		return flagErrorf("invalid arguments: %s = %s", "status", status)
 	}
 
 	return nil
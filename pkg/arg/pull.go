@@ -0,0 +1,25 @@
+package arg
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Pull cmd argument checking
+func Pull(cmd *cobra.Command, args []string) error {
+	err := checkIDOrAll(cmd)
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.Flags().GetString("lang")
+	if err != nil {
+		return err
+	}
+
+	_, err = cmd.Flags().GetBool("merge")
+	if err != nil {
+		return err
+	}
+
+	return checkBulk(cmd)
+}
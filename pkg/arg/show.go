@@ -1,8 +1,6 @@
 package arg
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
@@ -18,7 +16,7 @@ func Show(cmd *cobra.Command, args []string) error {
 	}
 
 	if id == 0 && !random {
-		return fmt.Errorf("invalid arguments: either 'id', 'random' should be applied")
+		return flagErrorf("invalid arguments: either 'id', 'random' should be applied")
 	}
 
 	_, err = cmd.Flags().GetString("language")
@@ -0,0 +1,35 @@
+package arg
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Submissions cmd argument checking
+func Submissions(cmd *cobra.Command, args []string) error {
+	id, err := cmd.Flags().GetInt("id")
+	if err != nil {
+		return err
+	}
+	if id <= 0 {
+		return flagErrorf("missing required parameter: 'id'")
+	}
+
+	limit, err := cmd.Flags().GetInt("limit")
+	if err != nil {
+		return err
+	}
+	if limit < 0 {
+		return flagErrorf("invalid arguments: %s = %d", "limit", limit)
+	}
+
+	return checkOutput(cmd)
+}
+
+// Submission cmd argument checking
+func Submission(cmd *cobra.Command, args []string) error {
+	if len(args) != 1 || args[0] == "" {
+		return flagErrorf("missing required argument: 'submission-id'")
+	}
+
+	return checkOutput(cmd)
+}
@@ -1,28 +1,31 @@
 package arg
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
 // Submit cmd argument checking
 func Submit(cmd *cobra.Command, args []string) error {
-	id, err := cmd.Flags().GetInt("id")
+	err := checkSource(cmd, args)
 	if err != nil {
 		return err
 	}
-	if id == 0 {
-		return fmt.Errorf("missing required parameter: 'id'")
-	}
 
-	file, err := cmd.Flags().GetString("file")
+	_, err = cmd.Flags().GetBool("save-failing")
 	if err != nil {
 		return err
 	}
-	if file == "" {
-		return fmt.Errorf("missing required parameter: 'file'")
+
+	printBundle, _ := cmd.Flags().GetBool("print-bundle")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	if printBundle && dryRun {
+		return flagErrorf("invalid arguments: %s and %s are exclusive", "print-bundle", "dry-run")
+	}
+
+	err = checkStdout(cmd)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return checkOutput(cmd)
 }
@@ -0,0 +1,96 @@
+package arg
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// TestAdd cmd argument checking
+func TestAdd(cmd *cobra.Command, args []string) error {
+	err := checkID(cmd)
+	if err != nil {
+		return err
+	}
+
+	testInput, err := cmd.Flags().GetString("test_input")
+	if err != nil {
+		return err
+	}
+	testFile, err := cmd.Flags().GetString("test_file")
+	if err != nil {
+		return err
+	}
+	if testInput == "" && testFile == "" {
+		return flagErrorf("missing required parameter: either 'test_input', 'test_file' should be applied")
+	}
+	if testInput != "" && testFile != "" {
+		return flagErrorf("invalid arguments: only one of 'test_input', 'test_file' should be applied")
+	}
+
+	_, err = cmd.Flags().GetString("expected")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TestList cmd argument checking
+func TestList(cmd *cobra.Command, args []string) error {
+	return checkID(cmd)
+}
+
+// TestRecord cmd argument checking
+func TestRecord(cmd *cobra.Command, args []string) error {
+	err := checkID(cmd)
+	if err != nil {
+		return err
+	}
+
+	file, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		return flagErrorf("missing required parameter: 'file'")
+	}
+
+	_, err = cmd.Flags().GetBool("refresh")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// TestRemove cmd argument checking
+func TestRemove(cmd *cobra.Command, args []string) error {
+	err := checkID(cmd)
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return flagErrorf("missing required argument: test case number")
+	}
+	for _, a := range args {
+		n, err := strconv.Atoi(a)
+		if err != nil || n < 1 {
+			return flagErrorf("invalid arguments: test case number = %s", a)
+		}
+	}
+
+	return nil
+}
+
+func checkID(cmd *cobra.Command) error {
+	id, err := cmd.Flags().GetInt("id")
+	if err != nil {
+		return err
+	}
+	if id == 0 {
+		return flagErrorf("missing required parameter: 'id'")
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package arg
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// Watch cmd argument checking
+func Watch(cmd *cobra.Command, args []string) error {
+	err := checkSource(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	testInput, err := cmd.Flags().GetString("test_input")
+	if err != nil {
+		return err
+	}
+	testFile, err := cmd.Flags().GetString("test_file")
+	if err != nil {
+		return err
+	}
+	if testInput != "" && testFile != "" {
+		return flagErrorf("invalid arguments: only one of 'test_input', 'test_file' should be applied")
+	}
+	if testInput == "-" {
+		return flagErrorf("invalid arguments: %s = %s, stdin cannot be read on every run", "test_input", testInput)
+	}
+	if testFile == "-" {
+		return flagErrorf("invalid arguments: %s = %s, stdin cannot be read on every run", "test_file", testFile)
+	}
+
+	debounce, err := cmd.Flags().GetDuration("debounce")
+	if err != nil {
+		return err
+	}
+	if debounce < 0 {
+		return flagErrorf("invalid arguments: %s = %s", "debounce", debounce)
+	}
+
+	return checkStdout(cmd)
+}
@@ -0,0 +1,137 @@
+// Package bulk runs many independent API jobs with a bounded worker pool,
+// remembering completed jobs so that an interrupted run can be resumed;
+// failed requests are retried by the api client, not by the engine
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// DefaultWorkers is the number of jobs run concurrently unless Options say
+// otherwise
+const DefaultWorkers = 4
+
+// Job is a unit of work identified by a key stable across runs, its Run
+// returns a line to report on success
+type Job struct {
+	Key string
+	Run func(ctx context.Context) (string, error)
+}
+
+// Options tunes a bulk run
+type Options struct {
+	// Workers bounds the number of jobs run concurrently
+	Workers int
+	// StatePath records completed jobs to skip them when run again, it is
+	// removed once every job completed; empty disables resuming
+	StatePath string
+	// Output receives the line of every completed job
+	Output io.Writer
+	// Progress receives a progress bar, nil disables it
+	Progress io.Writer
+}
+
+// Failure is a job that failed
+type Failure struct {
+	Key string
+	Err error
+}
+
+// Report sums up a bulk run
+type Report struct {
+	Total int
+	// Done counts the jobs completed by this run
+	Done int
+	// Resumed counts the jobs completed by a previous run
+	Resumed  int
+	Failures []Failure
+}
+
+// Run runs jobs with the given options, returning the context error when
+// ctx is done before every job ran
+func Run(ctx context.Context, jobs []Job, opts Options) (*Report, error) {
+	if opts.Workers < 1 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.Output == nil {
+		opts.Output = io.Discard
+	}
+
+	st, err := loadState(opts.StatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Total: len(jobs)}
+	var pending []Job
+	for _, job := range jobs {
+		if st.done[job.Key] {
+			report.Resumed++
+			continue
+		}
+		pending = append(pending, job)
+	}
+
+	var mu sync.Mutex
+	bar := newProgress(opts.Progress, len(jobs), report.Resumed)
+	bar.draw()
+
+	queue := make(chan Job)
+	var wg sync.WaitGroup
+	for i := 0; i < opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				line, err := job.Run(ctx)
+
+				mu.Lock()
+				bar.clear()
+				switch {
+				case err != nil && ctx.Err() != nil:
+					// interrupted jobs are neither done nor failed
+				case err != nil:
+					report.Failures = append(report.Failures, Failure{Key: job.Key, Err: err})
+					bar.failed++
+				default:
+					err = st.markDone(job.Key)
+					if err != nil {
+						report.Failures = append(report.Failures, Failure{Key: job.Key, Err: err})
+						bar.failed++
+						break
+					}
+					report.Done++
+					bar.done++
+					if line != "" {
+						fmt.Fprintln(opts.Output, line)
+					}
+				}
+				bar.draw()
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for _, job := range pending {
+		select {
+		case queue <- job:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(queue)
+	wg.Wait()
+	bar.clear()
+
+	if ctx.Err() != nil {
+		return report, ctx.Err()
+	}
+	if len(report.Failures) == 0 {
+		err = st.remove()
+	}
+	return report, err
+}
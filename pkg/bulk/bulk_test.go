@@ -0,0 +1,178 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func jobs(n int, run func(key string) (string, error)) []Job {
+	var js []Job
+	for i := 1; i <= n; i++ {
+		key := fmt.Sprintf("job%d", i)
+		js = append(js, Job{Key: key, Run: func(ctx context.Context) (string, error) { return run(key) }})
+	}
+	return js
+}
+
+func sortedLines(s string) []string {
+	lines := strings.Fields(s)
+	sort.Strings(lines)
+	return lines
+}
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	report, err := Run(context.Background(), jobs(5, func(key string) (string, error) {
+		return key, nil
+	}), Options{Workers: 3, Output: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Total != 5 || report.Done != 5 || len(report.Failures) != 0 {
+		t.Errorf("report = %+v, want 5 jobs done", report)
+	}
+	if got := strings.Join(sortedLines(out.String()), " "); got != "job1 job2 job3 job4 job5" {
+		t.Errorf("output = %q, want every job line", got)
+	}
+}
+
+func TestRunBoundsWorkers(t *testing.T) {
+	var running, peak int32
+	_, err := Run(context.Background(), jobs(12, func(string) (string, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return "", nil
+	}), Options{Workers: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peak > 2 {
+		t.Errorf("%d jobs ran concurrently, want at most 2", peak)
+	}
+}
+
+func TestRunResumes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state")
+	fail := map[string]bool{"job2": true, "job4": true}
+	run := func(key string) (string, error) {
+		if fail[key] {
+			return "", errors.New("boom")
+		}
+		return key, nil
+	}
+
+	report, err := Run(context.Background(), jobs(5, run), Options{StatePath: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Done != 3 || len(report.Failures) != 2 {
+		t.Fatalf("report = %+v, want 3 done and 2 failures", report)
+	}
+	var failed []string
+	for _, f := range report.Failures {
+		failed = append(failed, f.Key)
+	}
+	sort.Strings(failed)
+	if strings.Join(failed, " ") != "job2 job4" {
+		t.Errorf("failures = %q, want job2 and job4", failed)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("state not kept after failures: %v", err)
+	}
+
+	fail = nil
+	var out bytes.Buffer
+	report, err = Run(context.Background(), jobs(5, run), Options{StatePath: path, Output: &out})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Resumed != 3 || report.Done != 2 || len(report.Failures) != 0 {
+		t.Errorf("report = %+v, want 3 resumed and 2 done", report)
+	}
+	if got := strings.Join(sortedLines(out.String()), " "); got != "job2 job4" {
+		t.Errorf("output = %q, want only the jobs failed before", got)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("state kept after every job completed: %v", err)
+	}
+}
+
+func TestRunCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	path := filepath.Join(t.TempDir(), "state")
+	var started int32
+	report, err := Run(ctx, jobs(10, func(key string) (string, error) {
+		if atomic.AddInt32(&started, 1) == 3 {
+			cancel()
+		}
+		return key, nil
+	}), Options{Workers: 1, StatePath: path})
+	if err != context.Canceled {
+		t.Fatalf("got error %v, want context.Canceled", err)
+	}
+	if report.Done+len(report.Failures) >= 10 || len(report.Failures) != 0 {
+		t.Errorf("report = %+v, want an interrupted run without failures", report)
+	}
+	st, err := loadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.done) != report.Done {
+		t.Errorf("state holds %d jobs, want the %d done", len(st.done), report.Done)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	l := NewLimiter(100, 2)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the burst is free, the 4 other tokens take 10ms each
+	if elapsed := time.Since(start); elapsed < 35*time.Millisecond {
+		t.Errorf("6 waits took %v, want about 40ms", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewLimiter(0.001, 1).Wait(ctx); err != nil {
+		t.Fatalf("first wait on a full bucket: %v", err)
+	}
+	l = NewLimiter(0.001, 1)
+	l.Wait(context.Background())
+	if err := l.Wait(ctx); err != context.Canceled {
+		t.Errorf("got error %v, want context.Canceled", err)
+	}
+}
+
+func TestRunDoesNotRetry(t *testing.T) {
+	var runs int32
+	report, err := Run(context.Background(), jobs(1, func(string) (string, error) {
+		atomic.AddInt32(&runs, 1)
+		return "", errors.New("503 Service Unavailable")
+	}), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if runs != 1 || len(report.Failures) != 1 {
+		t.Errorf("failing job ran %d times with %d failures, want once, retries are left to the client", runs, len(report.Failures))
+	}
+}
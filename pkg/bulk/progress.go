@@ -0,0 +1,50 @@
+package bulk
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// progressWidth is the number of cells of the progress bar
+const progressWidth = 30
+
+// progress draws a single line progress bar, redrawn in place
+type progress struct {
+	w      io.Writer
+	total  int
+	done   int
+	failed int
+	start  time.Time
+}
+
+func newProgress(w io.Writer, total int, done int) *progress {
+	return &progress{w: w, total: total, done: done, start: time.Now()}
+}
+
+func (p *progress) draw() {
+	if p.w == nil || p.total == 0 {
+		return
+	}
+
+	filled := p.done * progressWidth / p.total
+	line := fmt.Sprintf(
+		"[%s%s] %d/%d",
+		strings.Repeat("=", filled),
+		strings.Repeat(" ", progressWidth-filled),
+		p.done,
+		p.total,
+	)
+	if p.failed > 0 {
+		line += fmt.Sprintf(", %d failed", p.failed)
+	}
+	fmt.Fprintf(p.w, "\r\033[K%s", line)
+}
+
+// clear erases the bar so that a line can be printed in its place
+func (p *progress) clear() {
+	if p.w != nil && p.total > 0 {
+		fmt.Fprint(p.w, "\r\033[K")
+	}
+}
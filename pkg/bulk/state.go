@@ -0,0 +1,73 @@
+package bulk
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// state records the keys of completed jobs, one per line, so that an
+// interrupted run resumes where it stopped
+type state struct {
+	path string
+	done map[string]bool
+}
+
+// loadState reads the state at path, an empty path keeps no state
+func loadState(path string) (*state, error) {
+	s := &state{path: path, done: make(map[string]bool)}
+	if path == "" {
+		return s, nil
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key := strings.TrimSpace(scanner.Text()); key != "" {
+			s.done[key] = true
+		}
+	}
+	return s, scanner.Err()
+}
+
+// markDone appends key to the state
+func (s *state) markDone(key string) error {
+	s.done[key] = true
+	if s.path == "" {
+		return nil
+	}
+
+	err := os.MkdirAll(filepath.Dir(s.path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(key + "\n")
+	return err
+}
+
+// remove forgets the state once every job completed
+func (s *state) remove() error {
+	if s.path == "" {
+		return nil
+	}
+	err := os.Remove(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,503 @@
+// Package bundle inlines the declarations of local Go packages imported by a
+// solution, as the leetcode judge only accepts a single source file
+//
+// Local packages are configured as import path prefixes mapped to
+// directories. Only the declarations reachable from the solution are kept,
+// along with every method of a kept type, and they are renamed with their
+// package name as prefix when their name is already taken.
+package bundle
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// packageClause is prepended to solutions without one, leetcode Go snippets
+// have no package clause
+const packageClause = "package main\n"
+
+// unit is a top-level declaration copied as a whole into the bundle
+type unit struct {
+	pkg  *localPackage
+	file *ast.File
+	node ast.Node
+	// keyword prefixes specs of a grouped type or var declaration
+	keyword string
+	objects []types.Object
+}
+
+type importSpec struct {
+	name string
+	path string
+}
+
+type bundler struct {
+	*loader
+	units   map[types.Object]*unit
+	methods map[*types.TypeName][]*unit
+	indexed map[*localPackage]bool
+	reached map[*unit]bool
+	order   []*unit
+	imports map[importSpec]bool
+	names   map[types.Object]string
+}
+
+// Bundle returns the Go source src with the imports of local packages replaced
+// by their reachable declarations, packages maps import path prefixes to
+// local directories; src is returned unchanged when it imports none of them
+func Bundle(filename string, src string, packages map[string]string) (string, error) {
+	if len(packages) == 0 {
+		return src, nil
+	}
+
+	fset := token.NewFileSet()
+	prefixed := false
+	if _, err := parser.ParseFile(fset, filename, src, parser.PackageClauseOnly); err != nil {
+		src = packageClause + src
+		prefixed = true
+	}
+
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return "", err
+	}
+
+	b := &bundler{
+		loader:  newLoader(fset, packages),
+		units:   make(map[types.Object]*unit),
+		methods: make(map[*types.TypeName][]*unit),
+		indexed: make(map[*localPackage]bool),
+		reached: make(map[*unit]bool),
+		imports: make(map[importSpec]bool),
+		names:   make(map[types.Object]string),
+	}
+
+	local := false
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if !b.isLocal(path) {
+			continue
+		}
+		local = true
+		// surface errors of local packages, as those of the solution are ignored
+		if _, err := b.load(path); err != nil {
+			return "", err
+		}
+	}
+	if !local {
+		if prefixed {
+			return strings.TrimPrefix(src, packageClause), nil
+		}
+		return src, nil
+	}
+
+	// type errors of the solution are left to the judge to report
+	info := newInfo()
+	conf := types.Config{Importer: b.loader, Error: func(error) {}}
+	main, _ := conf.Check("main", fset, []*ast.File{f}, info)
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		if id, ok := n.(*ast.Ident); ok {
+			if obj := info.Uses[id]; obj != nil {
+				b.reach(obj)
+			}
+		}
+		return true
+	})
+
+	b.rename(main)
+
+	out, err := b.rewrite(f, []byte(src), info)
+	if err != nil {
+		return "", err
+	}
+	if formatted, err := format.Source([]byte(out)); err == nil {
+		out = string(formatted)
+	}
+	if prefixed {
+		out = strings.TrimLeft(strings.TrimPrefix(out, strings.TrimSuffix(packageClause, "\n")), "\n")
+	}
+	return out, nil
+}
+
+// index records the top-level declarations of lp
+func (b *bundler) index(lp *localPackage) {
+	if b.indexed[lp] {
+		return
+	}
+	b.indexed[lp] = true
+
+	for _, f := range lp.files {
+		for _, decl := range f.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				u := &unit{pkg: lp, file: f, node: d}
+				if d.Recv == nil {
+					if obj := lp.info.Defs[d.Name]; obj != nil {
+						u.objects = []types.Object{obj}
+						b.units[obj] = u
+					}
+					continue
+				}
+				if tn := receiverType(lp.info, d.Recv.List[0].Type); tn != nil {
+					b.methods[tn] = append(b.methods[tn], u)
+				}
+			case *ast.GenDecl:
+				if d.Tok == token.IMPORT {
+					continue
+				}
+				// constants share iota and implicit values within a group
+				if d.Tok == token.CONST || !d.Lparen.IsValid() {
+					b.addUnit(&unit{pkg: lp, file: f, node: d}, d.Specs)
+					continue
+				}
+				for _, spec := range d.Specs {
+					b.addUnit(&unit{pkg: lp, file: f, node: spec, keyword: d.Tok.String()}, []ast.Spec{spec})
+				}
+			}
+		}
+	}
+}
+
+func (b *bundler) addUnit(u *unit, specs []ast.Spec) {
+	var names []*ast.Ident
+	for _, spec := range specs {
+		switch s := spec.(type) {
+		case *ast.TypeSpec:
+			names = append(names, s.Name)
+		case *ast.ValueSpec:
+			names = append(names, s.Names...)
+		}
+	}
+	for _, name := range names {
+		if obj := u.pkg.info.Defs[name]; obj != nil {
+			u.objects = append(u.objects, obj)
+			b.units[obj] = u
+		}
+	}
+}
+
+func receiverType(info *types.Info, expr ast.Expr) *types.TypeName {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.ParenExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			tn, _ := info.Uses[e].(*types.TypeName)
+			return tn
+		default:
+			return nil
+		}
+	}
+}
+
+// reach marks the declaration of obj as part of the bundle when it belongs to
+// a local package, along with everything it refers to
+func (b *bundler) reach(obj types.Object) {
+	if obj.Pkg() == nil {
+		return
+	}
+	lp, ok := b.packages[obj.Pkg().Path()]
+	if !ok {
+		return
+	}
+	b.index(lp)
+
+	// methods are kept along with their receiver type
+	if fn, ok := obj.(*types.Func); ok {
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+			t := recv.Type()
+			if p, ok := t.(*types.Pointer); ok {
+				t = p.Elem()
+			}
+			if named, ok := t.(*types.Named); ok {
+				b.reach(named.Origin().Obj())
+			}
+			return
+		}
+	}
+
+	if u := b.units[obj]; u != nil {
+		b.reachUnit(u)
+	}
+}
+
+func (b *bundler) reachUnit(u *unit) {
+	if b.reached[u] {
+		return
+	}
+	b.reached[u] = true
+	b.order = append(b.order, u)
+
+	for _, obj := range u.objects {
+		if tn, ok := obj.(*types.TypeName); ok {
+			for _, m := range b.methods[tn] {
+				b.reachUnit(m)
+			}
+		}
+	}
+
+	ast.Inspect(u.node, func(n ast.Node) bool {
+		id, ok := n.(*ast.Ident)
+		if !ok {
+			return true
+		}
+		switch obj := u.pkg.info.Uses[id].(type) {
+		case nil:
+		case *types.PkgName:
+			if !b.isLocal(obj.Imported().Path()) {
+				b.imports[importSpec{name: obj.Name(), path: obj.Imported().Path()}] = true
+			}
+		default:
+			b.reach(obj)
+		}
+		return true
+	})
+}
+
+// rename picks the name of every bundled top-level object, prefixing it with
+// its package name when it collides with a name already taken
+func (b *bundler) rename(main *types.Package) {
+	taken := make(map[string]bool)
+	if main != nil {
+		for _, name := range main.Scope().Names() {
+			taken[name] = true
+		}
+	}
+
+	sort.SliceStable(b.order, func(i, j int) bool {
+		if b.order[i].pkg.path != b.order[j].pkg.path {
+			return b.order[i].pkg.path < b.order[j].pkg.path
+		}
+		return b.order[i].node.Pos() < b.order[j].node.Pos()
+	})
+
+	for _, u := range b.order {
+		for _, obj := range u.objects {
+			name := obj.Name()
+			if name == "_" {
+				continue
+			}
+			if taken[name] {
+				name = obj.Pkg().Name() + "_" + obj.Name()
+				for i := 2; taken[name]; i++ {
+					name = fmt.Sprintf("%s_%s%d", obj.Pkg().Name(), obj.Name(), i)
+				}
+			}
+			taken[name] = true
+			b.names[obj] = name
+		}
+	}
+}
+
+// name returns the bundled name of obj
+func (b *bundler) name(obj types.Object) string {
+	if name, ok := b.names[obj]; ok {
+		return name
+	}
+	return obj.Name()
+}
+
+type edit struct {
+	start int
+	end   int
+	text  string
+}
+
+func applyEdits(src []byte, start int, end int, edits []edit) string {
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var sb strings.Builder
+	at := start
+	for _, e := range edits {
+		sb.Write(src[at:e.start])
+		sb.WriteString(e.text)
+		at = e.end
+	}
+	sb.Write(src[at:end])
+	return sb.String()
+}
+
+// qualifiedEdits replaces the identifiers of bundled objects within node by
+// their bundled names, dropping the package qualifier of local packages
+func (b *bundler) qualifiedEdits(node ast.Node, info *types.Info) []edit {
+	var edits []edit
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			x, ok := n.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			pn, ok := info.Uses[x].(*types.PkgName)
+			if !ok || !b.isLocal(pn.Imported().Path()) {
+				return true
+			}
+			if obj := info.Uses[n.Sel]; obj != nil {
+				edits = append(edits, edit{b.offset(n.Pos()), b.offset(n.End()), b.name(obj)})
+			}
+			return false
+		case *ast.Ident:
+			obj := info.Uses[n]
+			if obj == nil {
+				obj = info.Defs[n]
+			}
+			if name, ok := b.names[obj]; ok && obj != nil && name != n.Name {
+				edits = append(edits, edit{b.offset(n.Pos()), b.offset(n.End()), name})
+			}
+		}
+		return true
+	})
+	return edits
+}
+
+func (b *bundler) offset(pos token.Pos) int {
+	return b.fset.File(pos).Offset(pos)
+}
+
+// rewrite returns the solution with local imports replaced by the standard
+// imports of bundled declarations, and bundled declarations appended
+func (b *bundler) rewrite(f *ast.File, src []byte, info *types.Info) (string, error) {
+	imports, err := b.missingImports(f, info)
+	if err != nil {
+		return "", err
+	}
+
+	edits := b.qualifiedEdits(f, info)
+
+	var lastImport *ast.GenDecl
+	removedLast := false
+	for _, decl := range f.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.IMPORT {
+			continue
+		}
+		lastImport = d
+
+		var local []ast.Spec
+		for _, spec := range d.Specs {
+			path, _ := strconv.Unquote(spec.(*ast.ImportSpec).Path.Value)
+			if b.isLocal(path) {
+				local = append(local, spec)
+			}
+		}
+		removedLast = len(local) == len(d.Specs)
+		if removedLast {
+			edits = append(edits, edit{b.offset(d.Pos()), b.offset(d.End()), ""})
+			continue
+		}
+		for _, spec := range local {
+			edits = append(edits, edit{b.offset(spec.Pos()), b.offset(spec.End()), ""})
+		}
+	}
+
+	if len(imports) > 0 {
+		decl := "import (\n\t" + strings.Join(imports, "\n\t") + "\n)"
+		switch {
+		case lastImport != nil && removedLast:
+			for i := range edits {
+				if edits[i].start == b.offset(lastImport.Pos()) && edits[i].end == b.offset(lastImport.End()) {
+					edits[i].text = decl
+				}
+			}
+		case lastImport != nil && lastImport.Lparen.IsValid():
+			at := b.offset(lastImport.Rparen)
+			edits = append(edits, edit{at, at, "\t" + strings.Join(imports, "\n\t") + "\n"})
+		case lastImport != nil:
+			at := b.offset(lastImport.End())
+			edits = append(edits, edit{at, at, "\n\n" + decl})
+		default:
+			at := b.offset(f.Name.End())
+			edits = append(edits, edit{at, at, "\n\n" + decl})
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(applyEdits(src, 0, len(src), edits))
+
+	path := ""
+	for _, u := range b.order {
+		if u.pkg.path != path {
+			path = u.pkg.path
+			fmt.Fprintf(&sb, "\n// inlined from %s\n", path)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(b.unitSource(u))
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
+
+// unitSource returns the source of u with bundled names applied
+func (b *bundler) unitSource(u *unit) string {
+	start := u.node.Pos()
+	switch n := u.node.(type) {
+	case *ast.FuncDecl:
+		if n.Doc != nil {
+			start = n.Doc.Pos()
+		}
+	case *ast.GenDecl:
+		if n.Doc != nil {
+			start = n.Doc.Pos()
+		}
+	}
+
+	text := applyEdits(u.pkg.srcs[u.file], b.offset(start), b.offset(u.node.End()), b.qualifiedEdits(u.node, u.pkg.info))
+	if u.keyword != "" {
+		text = u.keyword + " " + text
+	}
+	return text
+}
+
+// missingImports returns the import specs of the standard packages used by
+// bundled declarations and not yet imported by the solution
+func (b *bundler) missingImports(f *ast.File, info *types.Info) ([]string, error) {
+	imported := make(map[string]string)
+	for _, spec := range f.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		if b.isLocal(path) {
+			continue
+		}
+		var pn types.Object
+		if spec.Name != nil {
+			pn = info.Defs[spec.Name]
+		} else {
+			pn = info.Implicits[spec]
+		}
+		if pn != nil {
+			imported[pn.Name()] = path
+		}
+	}
+
+	var specs []string
+	for spec := range b.imports {
+		path, ok := imported[spec.name]
+		if ok && path == spec.path {
+			continue
+		}
+		if ok {
+			return nil, fmt.Errorf("cannot bundle: %s is imported as %s by the solution, bundled code needs %s", path, spec.name, spec.path)
+		}
+
+		line := strconv.Quote(spec.path)
+		if spec.path != spec.name && !strings.HasSuffix(spec.path, "/"+spec.name) {
+			line = spec.name + " " + line
+		}
+		specs = append(specs, line)
+	}
+	sort.Strings(specs)
+	return specs, nil
+}
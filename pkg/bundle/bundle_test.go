@@ -0,0 +1,102 @@
+package bundle
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+var testPackages = map[string]string{"example.com/lib": "testdata/lib"}
+
+func bundleOrFail(t *testing.T, src string) string {
+	t.Helper()
+	out, err := Bundle("solution.go", src, testPackages)
+	if err != nil {
+		t.Fatalf("Bundle: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "", packageClause+out, 0); err != nil {
+		t.Fatalf("bundled source does not parse: %v\n%s", err, out)
+	}
+	return out
+}
+
+func assertContains(t *testing.T, out string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		if !strings.Contains(out, w) {
+			t.Errorf("bundled source lacks %q:\n%s", w, out)
+		}
+	}
+}
+
+func assertNotContains(t *testing.T, out string, unwanted ...string) {
+	t.Helper()
+	for _, w := range unwanted {
+		if strings.Contains(out, w) {
+			t.Errorf("bundled source contains %q:\n%s", w, out)
+		}
+	}
+}
+
+func TestBundleWithoutLocalImports(t *testing.T) {
+	src := "import \"sort\"\n\nfunc f(xs []int) { sort.Ints(xs) }\n"
+	out := bundleOrFail(t, src)
+	if out != src {
+		t.Errorf("got %q, want source unchanged", out)
+	}
+}
+
+func TestBundleInlinesReachableDeclarations(t *testing.T) {
+	out := bundleOrFail(t, `import "example.com/lib/util"
+
+func maxOf(a, b int) int { return util.Max(a, b) }
+`)
+	assertContains(t, out, "return Max(a, b)", "func Max(a, b int) int")
+	assertNotContains(t, out, `"example.com/lib/util"`, "util.", "func Shuffle", "math/rand")
+}
+
+func TestBundleKeepsMethodsAndDependencies(t *testing.T) {
+	out := bundleOrFail(t, `import "example.com/lib/uf"
+
+func count(n int) int { u := uf.New(n); u.Union(0, 1); return n }
+`)
+	assertContains(t, out,
+		"u := New(n)",
+		"type UnionFind struct",
+		"func (u *UnionFind) Find(x int) int",
+		"func (u *UnionFind) Union(a, b int) bool",
+		"var Count int",
+		"Count++",
+	)
+	assertNotContains(t, out, "func unused", "Limit", "util.")
+}
+
+func TestBundleRenamesCollisions(t *testing.T) {
+	out := bundleOrFail(t, `import "example.com/lib/util"
+
+func Max(xs []int) int { return util.Max(xs[0], xs[1]) }
+`)
+	assertContains(t, out,
+		"func Max(xs []int) int { return util_Max(xs[0], xs[1]) }",
+		"func util_Max(a, b int) int",
+	)
+}
+
+func TestBundleAddsStandardImports(t *testing.T) {
+	out := bundleOrFail(t, `import "example.com/lib/util"
+
+func mix(xs []int) { util.Shuffle(xs) }
+`)
+	assertContains(t, out, `"math/rand"`, "func Shuffle(xs []int)")
+}
+
+func TestBundleImportCycle(t *testing.T) {
+	_, err := Bundle("solution.go", `import "example.com/lib/cyca"
+
+func f() int { return cyca.A() }
+`, testPackages)
+	if err == nil || !strings.Contains(err.Error(), "import cycle") {
+		t.Fatalf("got error %v, want import cycle", err)
+	}
+}
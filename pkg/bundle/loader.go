@@ -0,0 +1,134 @@
+package bundle
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// localPackage is a type checked package of the configured local library
+type localPackage struct {
+	path  string
+	pkg   *types.Package
+	files []*ast.File
+	srcs  map[*ast.File][]byte
+	info  *types.Info
+}
+
+// loader type checks local packages from source, std packages come from the
+// default importer
+type loader struct {
+	fset     *token.FileSet
+	roots    map[string]string
+	std      types.Importer
+	packages map[string]*localPackage
+	loading  map[string]bool
+}
+
+func newLoader(fset *token.FileSet, roots map[string]string) *loader {
+	return &loader{
+		fset:     fset,
+		roots:    roots,
+		std:      importer.ForCompiler(fset, "gc", nil),
+		packages: make(map[string]*localPackage),
+		loading:  make(map[string]bool),
+	}
+}
+
+// dir returns the directory of the local package path, or false when path is
+// not under any configured prefix
+func (l *loader) dir(path string) (string, bool) {
+	// the longest matching prefix wins
+	prefixes := make([]string, 0, len(l.roots))
+	for prefix := range l.roots {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return len(prefixes[i]) > len(prefixes[j]) })
+
+	for _, prefix := range prefixes {
+		if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+			continue
+		}
+		root := l.roots[prefix]
+		if strings.HasPrefix(root, "~/") {
+			root = filepath.Join(os.Getenv("HOME"), root[2:])
+		}
+		return filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(path, prefix))), true
+	}
+	return "", false
+}
+
+func (l *loader) isLocal(path string) bool {
+	_, ok := l.dir(path)
+	return ok
+}
+
+// Import implements types.Importer
+func (l *loader) Import(path string) (*types.Package, error) {
+	if !l.isLocal(path) {
+		return l.std.Import(path)
+	}
+	lp, err := l.load(path)
+	if err != nil {
+		return nil, err
+	}
+	return lp.pkg, nil
+}
+
+func (l *loader) load(path string) (*localPackage, error) {
+	if lp, ok := l.packages[path]; ok {
+		return lp, nil
+	}
+	if l.loading[path] {
+		return nil, fmt.Errorf("import cycle through %s", path)
+	}
+	l.loading[path] = true
+	defer delete(l.loading, path)
+
+	dir, _ := l.dir(path)
+	bp, err := build.Default.ImportDir(dir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load %s from %s: %v", path, dir, err)
+	}
+
+	lp := &localPackage{path: path, srcs: make(map[*ast.File][]byte)}
+	for _, name := range bp.GoFiles {
+		fp := filepath.Join(dir, name)
+		src, err := os.ReadFile(fp)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(l.fset, fp, src, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		lp.files = append(lp.files, f)
+		lp.srcs[f] = src
+	}
+
+	lp.info = newInfo()
+	conf := types.Config{Importer: l}
+	lp.pkg, err = conf.Check(path, l.fset, lp.files, lp.info)
+	if err != nil {
+		return nil, fmt.Errorf("cannot bundle %s: %v", path, err)
+	}
+
+	l.packages[path] = lp
+	return lp, nil
+}
+
+func newInfo() *types.Info {
+	return &types.Info{
+		Defs:      make(map[*ast.Ident]types.Object),
+		Uses:      make(map[*ast.Ident]types.Object),
+		Implicits: make(map[ast.Node]types.Object),
+	}
+}
@@ -0,0 +1,5 @@
+package cyca
+
+import "example.com/lib/cycb"
+
+func A() int { return cycb.B() }
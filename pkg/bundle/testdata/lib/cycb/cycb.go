@@ -0,0 +1,5 @@
+package cycb
+
+import "example.com/lib/cyca"
+
+func B() int { return cyca.A() }
@@ -0,0 +1,49 @@
+package uf
+
+import "example.com/lib/util"
+
+// UnionFind is a disjoint set forest
+type UnionFind struct {
+	parent []int
+	size   []int
+}
+
+// New returns n singleton sets
+func New(n int) *UnionFind {
+	u := &UnionFind{parent: make([]int, n), size: make([]int, n)}
+	for i := range u.parent {
+		u.parent[i] = i
+		u.size[i] = 1
+	}
+	return u
+}
+
+// Find returns the root of x
+func (u *UnionFind) Find(x int) int {
+	for u.parent[x] != x {
+		x = u.parent[x]
+	}
+	return x
+}
+
+// Union merges the sets of a and b
+func (u *UnionFind) Union(a, b int) bool {
+	a, b = u.Find(a), u.Find(b)
+	if a == b {
+		return false
+	}
+	if u.size[a] < u.size[b] {
+		a, b = b, a
+	}
+	u.parent[b] = a
+	u.size[a] += u.size[b]
+	util.Count++
+	return true
+}
+
+func unused() int { return Limit }
+
+const (
+	Limit = iota + 10
+	Other
+)
@@ -0,0 +1,15 @@
+package util
+
+import "math/rand"
+
+// Count counts unions
+var Count int
+
+func Max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func Shuffle(xs []int) { rand.Shuffle(len(xs), func(i, j int) { xs[i], xs[j] = xs[j], xs[i] }) }
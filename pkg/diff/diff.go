@@ -0,0 +1,277 @@
+// Package diff compares expected and actual leetcode answers, structurally
+// when both parse as json-like values and line by line otherwise
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// floatTolerance is the absolute error under which two numbers are equal,
+// as leetcode accepts floating point answers within 1e-5
+const floatTolerance = 1e-5
+
+// Diff is the first difference between an expected and an actual answer
+type Diff struct {
+	Equal bool
+	// Structural is false when an answer could not be parsed, Lines then
+	// holds a line diff of the raw answers
+	Structural bool
+	// Path is the index path of the first differing element, empty when the
+	// answers differ at the top level
+	Path []int
+	// LengthMismatch is set when the arrays at Path only differ in length
+	LengthMismatch bool
+	ExpectedLen    int
+	ActualLen      int
+	Lines          []Line
+
+	expected interface{}
+	actual   interface{}
+}
+
+// Line is a single line of a line diff, Op is one of ' ', '-' or '+'
+type Line struct {
+	Op   byte
+	Text string
+}
+
+// Compare returns the first difference between expected and actual
+func Compare(expected string, actual string) *Diff {
+	ev, eErr := parse(expected)
+	av, aErr := parse(actual)
+	if eErr != nil || aErr != nil {
+		lines := lineDiff(expected, actual)
+		return &Diff{Equal: expected == actual, Lines: lines}
+	}
+
+	d := &Diff{Structural: true, expected: ev, actual: av}
+	d.Equal = d.compare(ev, av, nil)
+	return d
+}
+
+func parse(answer string) (interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(answer))
+	dec.UseNumber()
+
+	var v interface{}
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("trailing data after answer")
+	}
+	return v, nil
+}
+
+// compare walks e and a in parallel, recording the first difference
+func (d *Diff) compare(e interface{}, a interface{}, path []int) bool {
+	ea, eok := e.([]interface{})
+	aa, aok := a.([]interface{})
+	if eok && aok {
+		for i := 0; i < len(ea) && i < len(aa); i++ {
+			if !d.compare(ea[i], aa[i], append(path, i)) {
+				return false
+			}
+		}
+		if len(ea) != len(aa) {
+			d.Path = append([]int(nil), path...)
+			d.LengthMismatch = true
+			d.ExpectedLen = len(ea)
+			d.ActualLen = len(aa)
+			return false
+		}
+		return true
+	}
+
+	if scalarEqual(e, a) {
+		return true
+	}
+	d.Path = append([]int(nil), path...)
+	return false
+}
+
+// scalarEqual compares numbers within floatTolerance when either has a
+// fraction or an exponent, and integers exactly
+func scalarEqual(e interface{}, a interface{}) bool {
+	en, eok := e.(json.Number)
+	an, aok := a.(json.Number)
+	if eok && aok {
+		if en == an {
+			return true
+		}
+		if ei, ok := integer(en); ok {
+			if ai, ok := integer(an); ok {
+				return ei.Cmp(ai) == 0
+			}
+		}
+		ef, err1 := strconv.ParseFloat(string(en), 64)
+		af, err2 := strconv.ParseFloat(string(an), 64)
+		return err1 == nil && err2 == nil && math.Abs(ef-af) <= floatTolerance
+	}
+
+	eb, _ := json.Marshal(e)
+	ab, _ := json.Marshal(a)
+	return bytes.Equal(eb, ab)
+}
+
+// integer returns the value of n when it is written without fraction nor
+// exponent
+func integer(n json.Number) (*big.Int, bool) {
+	if strings.ContainsAny(string(n), ".eE") {
+		return nil, false
+	}
+	return new(big.Int).SetString(string(n), 10)
+}
+
+// Element returns the expected and actual values at Path, rendered compactly
+func (d *Diff) Element() (string, string) {
+	return encode(at(d.expected, d.Path)), encode(at(d.actual, d.Path))
+}
+
+// Summary describes the first difference in one line
+func (d *Diff) Summary() string {
+	if d.Equal {
+		return "answers are identical"
+	}
+	if !d.Structural {
+		return "answers differ, showing line diff"
+	}
+
+	where := "at top level"
+	if len(d.Path) > 0 {
+		where = "at " + FormatPath(d.Path)
+	}
+
+	if d.LengthMismatch {
+		return fmt.Sprintf("length mismatch %s: expected %d elements, got %d", where, d.ExpectedLen, d.ActualLen)
+	}
+
+	e, a := d.Element()
+	return fmt.Sprintf("first difference %s: expected %s, got %s", where, e, a)
+}
+
+// Highlight renders both answers compactly with the first differing element
+// wrapped by mark; on a length mismatch the first extra element is marked on
+// the longer side and the whole array on the shorter one
+func (d *Diff) Highlight(mark func(string) string) (string, string) {
+	path := d.Path
+	if d.LengthMismatch {
+		first := d.ExpectedLen
+		if d.ActualLen < first {
+			first = d.ActualLen
+		}
+		path = append(append([]int(nil), d.Path...), first)
+	}
+	return highlight(d.expected, path, mark), highlight(d.actual, path, mark)
+}
+
+// FormatPath renders an index path as `[i][j]`
+func FormatPath(path []int) string {
+	var b strings.Builder
+	for _, i := range path {
+		fmt.Fprintf(&b, "[%d]", i)
+	}
+	return b.String()
+}
+
+func at(v interface{}, path []int) interface{} {
+	for _, i := range path {
+		arr, ok := v.([]interface{})
+		if !ok || i >= len(arr) {
+			return nil
+		}
+		v = arr[i]
+	}
+	return v
+}
+
+func encode(v interface{}) string {
+	if arr, ok := v.([]interface{}); ok {
+		items := make([]string, 0, len(arr))
+		for _, item := range arr {
+			items = append(items, encode(item))
+		}
+		return "[" + strings.Join(items, ",") + "]"
+	}
+
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(v)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func highlight(v interface{}, path []int, mark func(string) string) string {
+	if len(path) == 0 {
+		return mark(encode(v))
+	}
+
+	arr, ok := v.([]interface{})
+	if !ok || path[0] >= len(arr) {
+		// the element is missing on this side, mark the enclosing value
+		return mark(encode(v))
+	}
+
+	items := make([]string, 0, len(arr))
+	for i, item := range arr {
+		if i == path[0] {
+			items = append(items, highlight(item, path[1:], mark))
+		} else {
+			items = append(items, encode(item))
+		}
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+// lineDiff is a longest common subsequence diff of the lines of e and a
+func lineDiff(e string, a string) []Line {
+	el := strings.Split(e, "\n")
+	al := strings.Split(a, "\n")
+
+	lcs := make([][]int, len(el)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(al)+1)
+	}
+	for i := len(el) - 1; i >= 0; i-- {
+		for j := len(al) - 1; j >= 0; j-- {
+			if el[i] == al[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var lines []Line
+	i, j := 0, 0
+	for i < len(el) && j < len(al) {
+		switch {
+		case el[i] == al[j]:
+			lines = append(lines, Line{Op: ' ', Text: el[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, Line{Op: '-', Text: el[i]})
+			i++
+		default:
+			lines = append(lines, Line{Op: '+', Text: al[j]})
+			j++
+		}
+	}
+	for ; i < len(el); i++ {
+		lines = append(lines, Line{Op: '-', Text: el[i]})
+	}
+	for ; j < len(al); j++ {
+		lines = append(lines, Line{Op: '+', Text: al[j]})
+	}
+	return lines
+}
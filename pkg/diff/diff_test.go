@@ -0,0 +1,75 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		expected, actual string
+		equal            bool
+		summary          string
+	}{
+		{"[1,2]", "[1,2]", true, "answers are identical"},
+		{"[1, 2]", "[1,2]", true, "answers are identical"},
+		{"[[1,2],[3,4]]", "[[1,2],[3,5]]", false, "first difference at [1][1]: expected 4, got 5"},
+		{"[1,2,3]", "[1,2]", false, "length mismatch at top level: expected 3 elements, got 2"},
+		{"[[1],[2,3]]", "[[1],[2]]", false, "length mismatch at [1]: expected 2 elements, got 1"},
+		{"true", "false", false, "first difference at top level: expected true, got false"},
+		{`"abc"`, `"abd"`, false, `first difference at top level: expected "abc", got "abd"`},
+		{"0.333330", "0.33333", true, "answers are identical"},
+		{"2.00000", "2.000004", true, "answers are identical"},
+		{"2.00000", "2.0001", false, "first difference at top level: expected 2.00000, got 2.0001"},
+		{"100000000000000000", "100000000000000001", false, "first difference at top level: expected 100000000000000000, got 100000000000000001"},
+		{"[1000000]", "[1000001]", false, "first difference at [0]: expected 1000000, got 1000001"},
+		{"0", "-0", true, "answers are identical"},
+		{"3", "3.000001", true, "answers are identical"},
+		{"1e2", "100", true, "answers are identical"},
+		{"a\nb", "a\nc", false, "answers differ, showing line diff"},
+	}
+	for _, tt := range tests {
+		d := Compare(tt.expected, tt.actual)
+		if d.Equal != tt.equal {
+			t.Errorf("Compare(%q, %q).Equal = %v, want %v", tt.expected, tt.actual, d.Equal, tt.equal)
+		}
+		if got := d.Summary(); got != tt.summary {
+			t.Errorf("Compare(%q, %q).Summary() = %q, want %q", tt.expected, tt.actual, got, tt.summary)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	mark := func(s string) string { return "<" + s + ">" }
+	tests := []struct {
+		expected, actual string
+		e, a             string
+	}{
+		{"[[1,2],[3,4]]", "[[1,2],[3,5]]", "[[1,2],[3,<4>]]", "[[1,2],[3,<5>]]"},
+		{"[1,2,3]", "[1,2]", "[1,2,<3>]", "<[1,2]>"},
+		{"1", "2", "<1>", "<2>"},
+	}
+	for _, tt := range tests {
+		e, a := Compare(tt.expected, tt.actual).Highlight(mark)
+		if e != tt.e || a != tt.a {
+			t.Errorf("Highlight of %q, %q = %q, %q, want %q, %q", tt.expected, tt.actual, e, a, tt.e, tt.a)
+		}
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	d := Compare("a\nb\nc", "a\nx\nc\nd")
+	want := []Line{{' ', "a"}, {'-', "b"}, {'+', "x"}, {' ', "c"}, {'+', "d"}}
+	if d.Structural || !reflect.DeepEqual(d.Lines, want) {
+		t.Errorf("got %+v, want line diff %+v", d.Lines, want)
+	}
+}
+
+func TestFormatPath(t *testing.T) {
+	if got := FormatPath([]int{0, 12}); got != "[0][12]" {
+		t.Errorf("FormatPath = %q, want [0][12]", got)
+	}
+	if got := FormatPath(nil); got != "" {
+		t.Errorf("FormatPath(nil) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,195 @@
+// Package journal keeps an append-only local log of judged runs, one json
+// entry per line in the data directory
+package journal
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+// Kinds of journal entries
+const (
+	KindSubmission     = "submission"
+	KindInterpretation = "interpretation"
+)
+
+// Entry is a judged run of a solution
+type Entry struct {
+	Time         time.Time   `json:"time"`
+	Kind         string      `json:"kind"`
+	ProblemID    int         `json:"problemId"`
+	Lang         string      `json:"lang"`
+	File         string      `json:"file,omitempty"`
+	CodeHash     string      `json:"codeHash"`
+	SubmissionID string      `json:"submissionId"`
+	Verdict      api.Verdict `json:"verdict"`
+	Runtime      string      `json:"runtime,omitempty"`
+	Memory       string      `json:"memory,omitempty"`
+	// percentiles are only known for accepted submissions
+	RuntimePercentile *float32 `json:"runtimePercentile,omitempty"`
+	MemoryPercentile  *float32 `json:"memoryPercentile,omitempty"`
+}
+
+// Filter selects journal entries, zero fields select everything
+type Filter struct {
+	ProblemID int
+	Since     time.Time
+	Verdict   api.Verdict
+	Kind      string
+}
+
+// Match reports whether e is selected by f
+func (f Filter) Match(e Entry) bool {
+	switch {
+	case f.ProblemID != 0 && e.ProblemID != f.ProblemID:
+		return false
+	case !f.Since.IsZero() && e.Time.Before(f.Since):
+		return false
+	case f.Verdict != api.VerdictUnknown && e.Verdict != f.Verdict:
+		return false
+	case f.Kind != "" && e.Kind != f.Kind:
+		return false
+	}
+	return true
+}
+
+// Select returns the entries matched by f, in journal order
+func Select(entries []Entry, f Filter) []Entry {
+	var selected []Entry
+	for _, e := range entries {
+		if f.Match(e) {
+			selected = append(selected, e)
+		}
+	}
+	return selected
+}
+
+// Append adds e at the end of the journal
+func Append(e Entry) error {
+	err := os.MkdirAll(filepath.Dir(utils.JournalPath), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(utils.JournalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(b, '\n'))
+	return err
+}
+
+// Load reads every journal entry in order, a missing journal yields none.
+// A submission is journaled once posted, with an unknown verdict, and again
+// once judged, the later entry then supersedes the earlier one.
+func Load() ([]Entry, error) {
+	f, err := os.Open(utils.JournalPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("invalid journal entry at %s:%d: %v", utils.JournalPath, n, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return supersede(entries), nil
+}
+
+// supersede drops the entries followed by another one of the same kind and
+// submission ID
+func supersede(entries []Entry) []Entry {
+	type key struct{ kind, id string }
+	last := make(map[key]int)
+	for i, e := range entries {
+		if e.SubmissionID != "" {
+			last[key{e.Kind, e.SubmissionID}] = i
+		}
+	}
+
+	kept := entries[:0]
+	for i, e := range entries {
+		if e.SubmissionID == "" || last[key{e.Kind, e.SubmissionID}] == i {
+			kept = append(kept, e)
+		}
+	}
+	return kept
+}
+
+// HashCode returns the hash of code once normalized, so that line endings and
+// trailing whitespace do not tell identical solutions apart
+func HashCode(code string) string {
+	lines := strings.Split(strings.ReplaceAll(code, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	normalized := strings.Trim(strings.Join(lines, "\n"), "\n")
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// FindSubmission returns the latest submission of the code hashed as hash to
+// problem id in language lang, or nil
+func FindSubmission(entries []Entry, id int, lang string, hash string) *Entry {
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Kind == KindSubmission && e.ProblemID == id && e.Lang == lang && e.CodeHash == hash {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// ParseSince reads the start of a journal query relative to now, either as an
+// age like `30m`, `12h`, `7d` or `2w`, or as a `2006-01-02` date
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if n, err := strconv.Atoi(strings.TrimSuffix(s, suffix)); err == nil && strings.HasSuffix(s, suffix) && n >= 0 {
+			return now.Add(-time.Duration(n) * unit), nil
+		}
+	}
+
+	age, err := time.ParseDuration(s)
+	if err != nil || age < 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected an age like 7d or a date like 2006-01-02", s)
+	}
+	return now.Add(-age), nil
+}
@@ -0,0 +1,98 @@
+package journal
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/api"
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"7d", now.AddDate(0, 0, -7)},
+		{"0d", now},
+		{"2w", now.AddDate(0, 0, -14)},
+		{"12h", now.Add(-12 * time.Hour)},
+		{"90m", now.Add(-90 * time.Minute)},
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		got, err := ParseSince(tt.in, now)
+		if err != nil {
+			t.Errorf("ParseSince(%q): %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseSince(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", "yesterday", "-3d", "-1h", "7x", "2024-13-01"} {
+		if _, err := ParseSince(in, now); err == nil {
+			t.Errorf("ParseSince(%q) succeeded, want an error", in)
+		}
+	}
+}
+
+func TestHashCode(t *testing.T) {
+	code := "func f() {\n\treturn\n}"
+	same := []string{
+		code,
+		"func f() {\r\n\treturn\r\n}",
+		"func f() {  \n\treturn\t\n}\n",
+		"\n\nfunc f() {\n\treturn\n}\n\n",
+	}
+	for _, s := range same {
+		if HashCode(s) != HashCode(code) {
+			t.Errorf("HashCode(%q) differs from HashCode(%q)", s, code)
+		}
+	}
+
+	different := []string{
+		"func f() {\nreturn\n}",
+		"func f() {\n\treturn 1\n}",
+		"func f() {\n\n\treturn\n}",
+	}
+	for _, s := range different {
+		if HashCode(s) == HashCode(code) {
+			t.Errorf("HashCode(%q) equals HashCode(%q)", s, code)
+		}
+	}
+}
+
+func TestLoadSupersedes(t *testing.T) {
+	path := utils.JournalPath
+	utils.JournalPath = filepath.Join(t.TempDir(), "journal.jsonl")
+	t.Cleanup(func() { utils.JournalPath = path })
+
+	appended := []Entry{
+		{Kind: KindSubmission, SubmissionID: "1"},
+		{Kind: KindInterpretation, SubmissionID: "runcode_2", Verdict: api.VerdictAccepted},
+		{Kind: KindSubmission, SubmissionID: "3"},
+		{Kind: KindSubmission, SubmissionID: "1", Verdict: api.VerdictWrongAnswer},
+	}
+	for _, e := range appended {
+		if err := Append(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range entries {
+		got = append(got, e.SubmissionID+":"+e.Verdict.Slug())
+	}
+	if want := "runcode_2:accepted 3:unknown 1:wrong_answer"; strings.Join(got, " ") != want {
+		t.Errorf("Load = %q, want %q", strings.Join(got, " "), want)
+	}
+}
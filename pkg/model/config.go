@@ -0,0 +1,46 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+// Config is the optional cli configuration stored in local as config.json
+type Config struct {
+	// Languages override or extend the built-in language registry by slug
+	Languages []Language `json:"languages"`
+	// ExtensionLanguages picks the language of a file extension shared by
+	// several languages, e.g. {".sql": "postgresql"}
+	ExtensionLanguages map[string]string `json:"extensionLanguages"`
+	// GoPackages maps import path prefixes of local Go packages to their
+	// directory, their imports are inlined into submitted Go solutions
+	GoPackages map[string]string `json:"goPackages"`
+	// VerifyBeforeSubmit makes `lc submit` default to --verify
+	VerifyBeforeSubmit bool `json:"verifyBeforeSubmit"`
+	// DuplicateSubmissions is what `lc submit` does with code already judged,
+	// either refuse, the default, or warn
+	DuplicateSubmissions string `json:"duplicateSubmissions"`
+}
+
+// GetConfig returns the local cli configuration, a missing config file yields
+// the default configuration
+func GetConfig() (*Config, error) {
+	c := Config{}
+
+	file, err := os.ReadFile(utils.ConfigPath)
+	if os.IsNotExist(err) {
+		return &c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(file, &c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %s: %v", utils.ConfigPath, err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,188 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Language is a programming language accepted by the leetcode judge
+type Language struct {
+	// Slug is the leetcode language slug, e.g. golang
+	Slug string `json:"slug"`
+	// Name is the display name, e.g. Go
+	Name string `json:"name"`
+	// Extensions are the file extensions of the language, the first one is
+	// used for generated source files
+	Extensions []string `json:"extensions"`
+	// LineComment starts a single line comment, e.g. //
+	LineComment string `json:"lineComment"`
+	// BlockComment holds the delimiters of a block comment, e.g. /* and */
+	BlockComment []string `json:"blockComment"`
+	// FileName is the source file name used when no sourceCodePath template
+	// is configured
+	FileName string `json:"fileName"`
+	// Scaffold surrounds the code snippet of generated source files, where
+	// `$code` stands for the snippet wrapped in `@lc code=start/end` markers
+	Scaffold string `json:"scaffold"`
+	// Transforms turn a source file into the code sent to the judge, in order
+	Transforms []Transform `json:"transforms"`
+}
+
+// Ext returns the extension of generated source files, without leading dot
+func (l Language) Ext() string {
+	if len(l.Extensions) == 0 {
+		return "txt"
+	}
+	return strings.TrimPrefix(l.Extensions[0], ".")
+}
+
+// HasExt reports whether ext is an extension of the language
+func (l Language) HasExt(ext string) bool {
+	for _, e := range l.Extensions {
+		if strings.EqualFold(e, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether s names the language, as its slug, its display name
+// or one of its extensions
+func (l Language) Matches(s string) bool {
+	return s == l.Slug || strings.EqualFold(s, l.Name) || l.HasExt("."+strings.TrimPrefix(s, "."))
+}
+
+// Wrap returns code wrapped in `@lc code=start/end` markers inside the
+// scaffold of the language
+func (l Language) Wrap(code string) string {
+	comment := l.LineComment
+	if comment == "" {
+		comment = "//"
+	}
+	marked := fmt.Sprintf("%s %s\n%s\n%s %s", comment, codeStartMarker, strings.TrimRight(code, "\n"), comment, codeEndMarker)
+
+	scaffold := l.Scaffold
+	if scaffold == "" {
+		scaffold = "$code\n"
+	}
+	return strings.Replace(scaffold, "$code", marked, 1)
+}
+
+// defaultLanguages is the built-in language registry, languages sharing an
+// extension are matched in this order
+var defaultLanguages = []Language{
+	{Slug: "cpp", Name: "C++", Extensions: []string{".cpp", ".cc", ".cxx"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.cpp", Scaffold: "#include <bits/stdc++.h>\nusing namespace std;\n\n$code\n"},
+	{Slug: "java", Name: "Java", Extensions: []string{".java"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "Solution.java", Scaffold: "import java.util.*;\n\n$code\n"},
+	{Slug: "python3", Name: "Python3", Extensions: []string{".py", ".py3"}, LineComment: "#", BlockComment: []string{`"""`, `"""`}, FileName: "solution.py", Scaffold: "from typing import *\n\n$code\n"},
+	{Slug: "python", Name: "Python", Extensions: []string{".py", ".py2"}, LineComment: "#", BlockComment: []string{`"""`, `"""`}, FileName: "solution.py", Scaffold: "from typing import *\n\n$code\n"},
+	{Slug: "pythondata", Name: "Pandas", Extensions: []string{".py"}, LineComment: "#", BlockComment: []string{`"""`, `"""`}, FileName: "solution.py"},
+	{Slug: "c", Name: "C", Extensions: []string{".c"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.c", Scaffold: "#include <stdbool.h>\n#include <stdlib.h>\n\n$code\n"},
+	{Slug: "csharp", Name: "C#", Extensions: []string{".cs"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "Solution.cs"},
+	{Slug: "javascript", Name: "JavaScript", Extensions: []string{".js"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.js"},
+	{Slug: "typescript", Name: "TypeScript", Extensions: []string{".ts"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.ts"},
+	{Slug: "php", Name: "PHP", Extensions: []string{".php"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.php"},
+	{Slug: "swift", Name: "Swift", Extensions: []string{".swift"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.swift"},
+	{Slug: "kotlin", Name: "Kotlin", Extensions: []string{".kt"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "Solution.kt", Scaffold: "$code\n\nfun main() {}\n"},
+	{Slug: "dart", Name: "Dart", Extensions: []string{".dart"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.dart"},
+	{Slug: "golang", Name: "Go", Extensions: []string{".go"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.go", Scaffold: "package main\n\n$code\n\nfunc main() {}\n"},
+	{Slug: "ruby", Name: "Ruby", Extensions: []string{".rb"}, LineComment: "#", BlockComment: []string{"=begin", "=end"}, FileName: "solution.rb"},
+	{Slug: "scala", Name: "Scala", Extensions: []string{".scala"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "Solution.scala"},
+	{Slug: "rust", Name: "Rust", Extensions: []string{".rs"}, LineComment: "//", BlockComment: []string{"/*", "*/"}, FileName: "solution.rs", Scaffold: "struct Solution;\n\n$code\n\nfn main() {}\n"},
+	{Slug: "racket", Name: "Racket", Extensions: []string{".rkt"}, LineComment: ";", BlockComment: []string{"#|", "|#"}, FileName: "solution.rkt"},
+	{Slug: "erlang", Name: "Erlang", Extensions: []string{".erl"}, LineComment: "%", FileName: "solution.erl"},
+	{Slug: "elixir", Name: "Elixir", Extensions: []string{".ex", ".exs"}, LineComment: "#", FileName: "solution.ex"},
+	{Slug: "mysql", Name: "MySQL", Extensions: []string{".sql"}, LineComment: "#", BlockComment: []string{"/*", "*/"}, FileName: "solution.sql"},
+	{Slug: "mssql", Name: "MS SQL Server", Extensions: []string{".sql"}, LineComment: "--", BlockComment: []string{"/*", "*/"}, FileName: "solution.sql"},
+	{Slug: "oraclesql", Name: "Oracle", Extensions: []string{".sql"}, LineComment: "--", BlockComment: []string{"/*", "*/"}, FileName: "solution.sql"},
+	{Slug: "postgresql", Name: "PostgreSQL", Extensions: []string{".sql"}, LineComment: "--", BlockComment: []string{"/*", "*/"}, FileName: "solution.sql"},
+	{Slug: "bash", Name: "Bash", Extensions: []string{".sh"}, LineComment: "#", FileName: "solution.sh"},
+}
+
+// GetLanguages returns the language registry, the built-in languages
+// overridden and extended by the local config
+func GetLanguages() ([]Language, error) {
+	c, err := GetConfig()
+	if err != nil {
+		return nil, err
+	}
+	return mergeLanguages(defaultLanguages, c.Languages), nil
+}
+
+// mergeLanguages overrides the non-empty fields of base languages by slug,
+// appending languages unknown to base
+func mergeLanguages(base []Language, overrides []Language) []Language {
+	languages := append([]Language(nil), base...)
+	for _, o := range overrides {
+		i := 0
+		for i < len(languages) && languages[i].Slug != o.Slug {
+			i++
+		}
+		if i == len(languages) {
+			languages = append(languages, o)
+			continue
+		}
+
+		l := &languages[i]
+		if o.Name != "" {
+			l.Name = o.Name
+		}
+		if len(o.Extensions) > 0 {
+			l.Extensions = o.Extensions
+		}
+		if o.LineComment != "" {
+			l.LineComment = o.LineComment
+		}
+		if len(o.BlockComment) == 2 {
+			l.BlockComment = o.BlockComment
+		}
+		if o.FileName != "" {
+			l.FileName = o.FileName
+		}
+		if o.Scaffold != "" {
+			l.Scaffold = o.Scaffold
+		}
+		if o.Transforms != nil {
+			l.Transforms = o.Transforms
+		}
+	}
+	return languages
+}
+
+// GetLanguage returns the registered language with the given slug
+func GetLanguage(slug string) (Language, error) {
+	languages, err := GetLanguages()
+	if err != nil {
+		return Language{}, err
+	}
+	for _, l := range languages {
+		if l.Slug == slug {
+			return l, nil
+		}
+	}
+	return Language{}, fmt.Errorf("unknown language %s", slug)
+}
+
+// GetLanguagesByExt returns the registered languages using the file extension
+// ext, the one configured in `extensionLanguages` first
+func GetLanguagesByExt(ext string) ([]Language, error) {
+	c, err := GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	ext = strings.ToLower(ext)
+	preferred := c.ExtensionLanguages[ext]
+
+	var matches []Language
+	for _, l := range mergeLanguages(defaultLanguages, c.Languages) {
+		if !l.HasExt(ext) {
+			continue
+		}
+		if l.Slug == preferred {
+			matches = append([]Language{l}, matches...)
+		} else {
+			matches = append(matches, l)
+		}
+	}
+	return matches, nil
+}
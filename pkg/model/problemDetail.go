@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
@@ -38,6 +39,7 @@ type ProblemDetail struct {
 	Solution              ProblemSolution       `json:"solution"`
 	Status                string                `json:"status"`
 	SampleTestCase        string                `json:"sampleTestCase"`
+	ExampleTestcases      string                `json:"exampleTestcases"`
 	MetaData              string                `json:"metaData"`
 	JudgerAvailable       bool                  `json:"judgerAvailable"`
 	JudgeType             string                `json:"judgeType"`
@@ -79,44 +81,6 @@ type ProblemCodeSnippets struct {
 	TypeName string `json:"__typename"`
 }
 
-// GetLanguageExt is a mapper function mapping question lang slug to file ext
-func (pcs ProblemCodeSnippets) GetLanguageExt() string {
-	switch pcs.LangSlug {
-	case "cpp":
-		return "cpp"
-	case "java":
-		return "java"
-	case "python":
-		return "py"
-	case "python3":
-		return "py3"
-	case "c":
-		return "c"
-	case "csharp":
-		return "cs"
-	case "javascript":
-		return "js"
-	case "ruby":
-		return "rb"
-	case "swift":
-		return "swift"
-	case "golang":
-		return "go"
-	case "scala":
-		return "scala"
-	case "kotlin":
-		return "kt"
-	case "rust":
-		return "rs"
-	case "php":
-		return "php"
-	case "typescript":
-		return "ts"
-	default:
-		return "txt"
-	}
-}
-
 // ProblemSolution is the response from leetcode GraphQL API
 // concerning problem solutions
 type ProblemSolution struct {
@@ -136,6 +100,20 @@ type ProblemStats struct {
 	AcceptRate         string `json:"acRate"`
 }
 
+// ProblemMetaData is the string response from leetcode GraphQL API
+// concerning the signature of the solution
+type ProblemMetaData struct {
+	Name         string `json:"name"`
+	SystemDesign bool   `json:"systemdesign"`
+	Params       []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"params"`
+	Return struct {
+		Type string `json:"type"`
+	} `json:"return"`
+}
+
 // GetDifficulty is a mapper function from problem Difficulty level to string
 func (pd ProblemDetail) GetDifficulty() string {
 	switch pd.Difficulty {
@@ -158,6 +136,51 @@ func (pd ProblemDetail) GetStats() (*ProblemStats, error) {
 	return ps, nil
 }
 
+// GetMetaData is a property function unmarshal json string field `metaData`
+func (pd ProblemDetail) GetMetaData() (*ProblemMetaData, error) {
+	pmd := &ProblemMetaData{}
+	err := json.Unmarshal([]byte(pd.MetaData), pmd)
+	if err != nil {
+		return nil, err
+	}
+	return pmd, nil
+}
+
+// SplitTestCases splits a newline separated data input into test cases, each
+// made of one line per solution parameter
+func (pd ProblemDetail) SplitTestCases(dataInput string) []string {
+	lines := strings.Split(strings.TrimRight(dataInput, "\n"), "\n")
+	n := 1
+	if pmd, err := pd.GetMetaData(); err == nil {
+		if pmd.SystemDesign { // method names and arguments
+			n = 2
+		} else if len(pmd.Params) > 0 {
+			n = len(pmd.Params)
+		}
+	}
+	if len(lines)%n != 0 {
+		return []string{dataInput}
+	}
+
+	var cases []string
+	for i := 0; i < len(lines); i += n {
+		cases = append(cases, strings.Join(lines[i:i+n], "\n"))
+	}
+	return cases
+}
+
+// GetExampleTestCases returns the inputs of every problem example, falling
+// back to the single sample test case
+func (pd ProblemDetail) GetExampleTestCases() []string {
+	if pd.ExampleTestcases != "" {
+		return pd.SplitTestCases(pd.ExampleTestcases)
+	}
+	if pd.SampleTestCase != "" {
+		return []string{pd.SampleTestCase}
+	}
+	return nil
+}
+
 // ExportDetail generate source code in local directory
 func (pd ProblemDetail) ExportDetail(language string) error {
 	sourceCodePath := ""
@@ -168,7 +191,7 @@ func (pd ProblemDetail) ExportDetail(language string) error {
 	}
 
 	if language != "" {
-		sourceCodePath, err = pd.generateSourceCode(t, language)
+		sourceCodePath, err = pd.generateSourceCode(t, language, true)
 		if err != nil {
 			return err
 		}
@@ -184,6 +207,30 @@ func (pd ProblemDetail) ExportDetail(language string) error {
 	return nil
 }
 
+// ExportDescription writes the markdown of the problem and, when it supports
+// language, its code template unless the source file exists already; it
+// returns the paths of the markdown and of the source file, if any
+func (pd ProblemDetail) ExportDescription(language string) (string, string, error) {
+	t, err := GetFileTemplate(pd)
+	if err != nil {
+		return "", "", err
+	}
+
+	sourceCodePath := ""
+	if language != "" && pd.SupportsLanguage(language) {
+		sourceCodePath, err = pd.generateSourceCode(t, language, false)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	err = pd.generateMarkdown(t, sourceCodePath)
+	if err != nil {
+		return "", sourceCodePath, err
+	}
+	return t.MarkdownPath, sourceCodePath, nil
+}
+
 func (pd ProblemDetail) generateMarkdown(t *FileTemplate, sourceCodePath string) error {
 	pds, err := pd.GetStats()
 	if err != nil {
@@ -223,40 +270,63 @@ func (pd ProblemDetail) generateMarkdown(t *FileTemplate, sourceCodePath string)
 	return nil
 }
 
-func (pd ProblemDetail) generateSourceCode(t *FileTemplate, language string) (string, error) {
+// generateSourceCode writes the code template of the problem in language,
+// leaving an existing source file alone unless overwrite is set
+func (pd ProblemDetail) generateSourceCode(t *FileTemplate, language string, overwrite bool) (string, error) {
+	// a language given by extension resolves like the extension of a source
+	// file, so that `py` picks python3 rather than the first python snippet
+	slug := language
+	if !pd.hasSnippet(language) {
+		if s, err := pd.GetLanguageSlug("." + language); err == nil {
+			slug = s
+		}
+	}
+
 	var supportedLanguage []string
 	for _, codeSnippet := range pd.CodeSnippets {
 		supportedLanguage = append(
 			supportedLanguage,
 			fmt.Sprintf("%s(%s)", codeSnippet.Lang, codeSnippet.LangSlug),
 		)
-		if codeSnippet.Lang == language || codeSnippet.LangSlug == language {
-			t.SourceCodePath = strings.ReplaceAll(
-				t.SourceCodePath,
-				"$ext",
-				codeSnippet.GetLanguageExt(),
-			)
-
-			err := os.MkdirAll(filepath.Dir(t.SourceCodePath), os.ModePerm)
-			if err != nil {
-				return "", fmt.Errorf(err.Error())
-			}
-			f, err := os.Create(t.SourceCodePath)
-			if err != nil {
-				return "", fmt.Errorf(err.Error())
-			}
 
-			defer f.Close()
+		l, err := GetLanguage(codeSnippet.LangSlug)
+		if err != nil {
+			l = Language{Slug: codeSnippet.LangSlug, Name: codeSnippet.Lang, LineComment: "//"}
+		}
+		if codeSnippet.Lang != language && codeSnippet.LangSlug != slug {
+			continue
+		}
 
-			_, err = f.WriteString(codeSnippet.Code)
-			if err != nil {
-				return "", fmt.Errorf(err.Error())
-			}
+		t.SourceCodePath = pd.sourceCodePath(t, l)
+		if _, err := os.Stat(t.SourceCodePath); err == nil && !overwrite {
+			return t.SourceCodePath, nil
+		}
 
-			f.Sync()
+		err = os.MkdirAll(filepath.Dir(t.SourceCodePath), os.ModePerm)
+		if err != nil {
+			return "", fmt.Errorf(err.Error())
+		}
+		f, err := os.Create(t.SourceCodePath)
+		if err != nil {
+			return "", fmt.Errorf(err.Error())
+		}
 
-			return t.SourceCodePath, nil
+		defer f.Close()
+
+		id, err := strconv.Atoi(pd.QuestionFrontendID)
+		if err != nil {
+			return "", err
+		}
+		header := SourceHeader{ID: id, Slug: pd.TitleSlug, Lang: codeSnippet.LangSlug}
+
+		_, err = f.WriteString(header.Format(l) + "\n\n" + l.Wrap(codeSnippet.Code))
+		if err != nil {
+			return "", fmt.Errorf(err.Error())
 		}
+
+		f.Sync()
+
+		return t.SourceCodePath, nil
 	}
 
 	errMessage := fmt.Sprintf("invalid language '%s' for problem: '%s'", language, pd.Title)
@@ -264,6 +334,37 @@ func (pd ProblemDetail) generateSourceCode(t *FileTemplate, language string) (st
 	return "", fmt.Errorf(errMessage)
 }
 
+// SupportsLanguage reports whether the problem has a code snippet in the
+// language of the given name, slug or file extension
+func (pd ProblemDetail) SupportsLanguage(language string) bool {
+	if pd.hasSnippet(language) {
+		return true
+	}
+	_, err := pd.GetLanguageSlug("." + language)
+	return err == nil
+}
+
+// hasSnippet reports whether the problem has a code snippet in the language
+// of the given name or slug
+func (pd ProblemDetail) hasSnippet(language string) bool {
+	for _, codeSnippet := range pd.CodeSnippets {
+		if codeSnippet.Lang == language || codeSnippet.LangSlug == language {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceCodePath returns the path of the source file in language l, from the
+// `sourceCodePath` template or else a directory per problem
+func (pd ProblemDetail) sourceCodePath(t *FileTemplate, l Language) string {
+	sourceCodePath := t.SourceCodePath
+	if sourceCodePath == "" {
+		sourceCodePath = fmt.Sprintf("./%s_%s/%s", paddedID(pd.QuestionFrontendID), pd.TitleSlug, l.FileName)
+	}
+	return strings.ReplaceAll(sourceCodePath, "$ext", l.Ext())
+}
+
 func (pd ProblemDetail) exportGenerateSummary(t *FileTemplate) {
 	var tags []string
 	for _, tag := range pd.TopicTags {
@@ -280,49 +381,21 @@ func (pd ProblemDetail) exportGenerateSummary(t *FileTemplate) {
 	)
 }
 
-// GetLanguageSlug is a mapper function mapping file ext to question slug, with checking
+// GetLanguageSlug returns the slug of the language of a file extension,
+// picking the first registered language supported by the problem
 func (pd ProblemDetail) GetLanguageSlug(ext string) (string, error) {
-	var slug string
-	switch ext {
-	case ".cpp":
-		slug = "cpp"
-	case ".java":
-		slug = "java"
-	case ".py":
-		slug = "python"
-	case ".py3":
-		slug = "python3"
-	case ".c":
-		slug = "c"
-	case ".cs":
-		slug = "csharp"
-	case ".js":
-		slug = "javascript"
-	case ".rb":
-		slug = "ruby"
-	case ".swift":
-		slug = "swift"
-	case ".go":
-		slug = "golang"
-	case ".scala":
-		slug = "scala"
-	case ".kt":
-		slug = "kotlin"
-	case ".rs":
-		slug = "rust"
-	case ".php":
-		slug = "php"
-	case ".ts":
-		slug = "typescript"
-	default:
-		slug = ""
+	languages, err := GetLanguagesByExt(ext)
+	if err != nil {
+		return "", err
 	}
 
-	for _, pcs := range pd.CodeSnippets {
-		if slug == pcs.LangSlug {
-			return slug, nil
+	for _, l := range languages {
+		for _, pcs := range pd.CodeSnippets {
+			if l.Slug == pcs.LangSlug {
+				return l.Slug, nil
+			}
 		}
 	}
 
-	return "", fmt.Errorf("question %s does not support file format %s", pd.QuestionID, ext)
+	return "", fmt.Errorf("question %s does not support file format %s", pd.QuestionFrontendID, ext)
 }
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Outcomes of exporting a solution into the workspace
+const (
+	// ExportWritten is a new source file
+	ExportWritten = "written"
+	// ExportMerged is an existing source file whose code region was replaced
+	ExportMerged = "merged"
+	// ExportUpToDate is an existing source file already holding the code
+	ExportUpToDate = "up to date"
+	// ExportSkipped is an existing source file left alone as its code differs
+	ExportSkipped = "skipped"
+)
+
+// Solution is code accepted by the judge for a problem
+type Solution struct {
+	SubmissionID string
+	Lang         string
+	Code         string
+}
+
+// ExportSolution writes solution s to the source file of the problem, along
+// with its markdown when missing. An existing source file holding other code
+// is skipped, unless merge replaces its `@lc code=start/end` region. It
+// returns the path of the source file and the outcome.
+func (pd ProblemDetail) ExportSolution(s Solution, merge bool) (string, string, error) {
+	t, err := getFileTemplate(pd, s.SubmissionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	l, err := GetLanguage(s.Lang)
+	if err != nil {
+		l = Language{Slug: s.Lang, Name: s.Lang, LineComment: "//", FileName: "solution.txt"}
+	}
+	fp := pd.sourceCodePath(t, l)
+
+	outcome, err := writeSolution(pd, fp, l, s, merge)
+	if err != nil {
+		return fp, "", err
+	}
+
+	if t.MarkdownPath != "" {
+		if _, err := os.Stat(t.MarkdownPath); os.IsNotExist(err) {
+			err = pd.generateMarkdown(t, fp)
+			if err != nil {
+				return fp, outcome, err
+			}
+		}
+	}
+	return fp, outcome, nil
+}
+
+// writeSolution writes s to fp in language l unless fp already holds it
+func writeSolution(pd ProblemDetail, fp string, l Language, s Solution, merge bool) (string, error) {
+	existing, err := os.ReadFile(fp)
+	if os.IsNotExist(err) {
+		id, err := strconv.Atoi(pd.QuestionFrontendID)
+		if err != nil {
+			return "", err
+		}
+		header := SourceHeader{ID: id, Slug: pd.TitleSlug, Lang: l.Slug}
+
+		err = os.MkdirAll(filepath.Dir(fp), os.ModePerm)
+		if err != nil {
+			return "", err
+		}
+		return ExportWritten, os.WriteFile(fp, []byte(header.Format(l)+"\n\n"+l.Wrap(s.Code)), 0644)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if sameCode(ExtractCode(string(existing)), s.Code) {
+		return ExportUpToDate, nil
+	}
+	if !merge {
+		return ExportSkipped, nil
+	}
+
+	merged, ok := ReplaceCode(string(existing), s.Code)
+	if !ok {
+		return "", fmt.Errorf("cannot merge into %s without @lc code=start/end markers", fp)
+	}
+	return ExportMerged, os.WriteFile(fp, []byte(merged), 0644)
+}
+
+// sameCode reports whether a and b only differ by line endings and
+// surrounding whitespace
+func sameCode(a string, b string) bool {
+	normalize := func(code string) string {
+		lines := strings.Split(strings.ReplaceAll(code, "\r\n", "\n"), "\n")
+		for i, line := range lines {
+			lines[i] = strings.TrimRight(line, " \t")
+		}
+		return strings.Trim(strings.Join(lines, "\n"), "\n")
+	}
+	return normalize(a) == normalize(b)
+}
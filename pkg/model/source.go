@@ -0,0 +1,259 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sourceHeaderLines bounds how far into a source file the header is looked up
+const sourceHeaderLines = 20
+
+var sourceHeaderRegexp = regexp.MustCompile(`@lc\s+(.*)$`)
+
+// Markers delimiting the region of a source file sent to the judge, anything
+// outside of them is local scaffolding
+const (
+	codeStartMarker = "@lc code=start"
+	codeEndMarker   = "@lc code=end"
+)
+
+// SourceHeader is the metadata written at the top of generated source files,
+// e.g. `// @lc id=1 slug=two-sum lang=golang`
+type SourceHeader struct {
+	ID   int
+	Slug string
+	Lang string
+}
+
+// Format renders the header as a line comment of language l
+func (sh SourceHeader) Format(l Language) string {
+	comment := l.LineComment
+	if comment == "" {
+		comment = "//"
+	}
+	return fmt.Sprintf("%s @lc id=%d slug=%s lang=%s", comment, sh.ID, sh.Slug, sh.Lang)
+}
+
+// ReadSourceHeader returns the metadata header of the source file fp, the
+// header is empty when fp has none
+func ReadSourceHeader(fp string) (SourceHeader, error) {
+	sh := SourceHeader{}
+
+	f, err := os.Open(fp)
+	if err != nil {
+		return sh, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for i := 0; i < sourceHeaderLines && scanner.Scan(); i++ {
+		m := sourceHeaderRegexp.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+
+		for _, field := range strings.Fields(m[1]) {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "id":
+				sh.ID, err = strconv.Atoi(value)
+				if err != nil {
+					return sh, fmt.Errorf("invalid @lc header in %s: id = %s", fp, value)
+				}
+			case "slug":
+				sh.Slug = value
+			case "lang":
+				sh.Lang = value
+			}
+		}
+		return sh, nil
+	}
+	return sh, scanner.Err()
+}
+
+// MatchSourceCodePath reverse matches fp against the `sourceCodePath` template,
+// recovering the fields filled in from `$questionID` and `$questionSlug`
+func MatchSourceCodePath(pattern string, fp string) (SourceHeader, bool) {
+	sh := SourceHeader{}
+	if pattern == "" {
+		return sh, false
+	}
+
+	pattern, err := filepath.Abs(pattern)
+	if err != nil {
+		return sh, false
+	}
+	fp, err = filepath.Abs(fp)
+	if err != nil {
+		return sh, false
+	}
+
+	expr := regexp.QuoteMeta(filepath.ToSlash(pattern))
+	expr = strings.ReplaceAll(expr, `\$questionID`, `(?P<id>\d+)`)
+	expr = strings.ReplaceAll(expr, `\$questionSlug`, `(?P<slug>[a-z0-9-]+)`)
+	expr = strings.ReplaceAll(expr, `\$submissionID`, `\d+`)
+	expr = strings.ReplaceAll(expr, `\$ext`, `\w+`)
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return sh, false
+	}
+
+	m := re.FindStringSubmatch(filepath.ToSlash(fp))
+	if m == nil {
+		return sh, false
+	}
+	for i, name := range re.SubexpNames() {
+		switch name {
+		case "id":
+			sh.ID, _ = strconv.Atoi(m[i])
+		case "slug":
+			sh.Slug = m[i]
+		}
+	}
+	return sh, sh.ID != 0 || sh.Slug != ""
+}
+
+// ResolveSource returns the problem and language of the source file fp, from
+// its metadata header or else from the `sourceCodePath` template
+func ResolveSource(fp string) (SourceHeader, error) {
+	sh, err := ReadSourceHeader(fp)
+	if err != nil || sh.ID != 0 {
+		return sh, err
+	}
+
+	t, err := readFileTemplate()
+	if err != nil {
+		return sh, nil
+	}
+	if matched, ok := MatchSourceCodePath(t.SourceCodePath, fp); ok {
+		sh.ID = matched.ID
+		if sh.Slug == "" {
+			sh.Slug = matched.Slug
+		}
+	}
+	return sh, nil
+}
+
+// GetSourceLanguage returns the language slug of the source file fp, taken
+// from its metadata header and falling back to its file extension
+func (pd ProblemDetail) GetSourceLanguage(fp string) (string, error) {
+	sh, err := ReadSourceHeader(fp)
+	if err != nil {
+		return "", err
+	}
+	if sh.Lang == "" {
+		return pd.GetLanguageSlug(filepath.Ext(fp))
+	}
+
+	for _, pcs := range pd.CodeSnippets {
+		if sh.Lang == pcs.LangSlug {
+			return sh.Lang, nil
+		}
+	}
+	return "", fmt.Errorf("question %s does not support language %s", pd.QuestionFrontendID, sh.Lang)
+}
+
+// ReadSourceCode returns the code of the source file fp in language lang sent
+// to the judge, as transformed by the `transforms` configured for lang; by
+// default the region between `@lc code=start` and `@lc code=end` markers is
+// kept and Go solutions get the local packages of `goPackages` inlined
+func ReadSourceCode(fp string, lang string) (string, error) {
+	file, err := os.ReadFile(fp)
+	if err != nil {
+		return "", err
+	}
+
+	c, err := GetConfig()
+	if err != nil {
+		return "", err
+	}
+	languages := mergeLanguages(defaultLanguages, c.Languages)
+
+	transforms := defaultTransforms(lang)
+	for _, l := range languages {
+		if l.Slug == lang && l.Transforms != nil {
+			transforms = l.Transforms
+		}
+	}
+
+	env := transformEnv{fp: fp, lang: lang, config: c}
+	code := string(file)
+	for _, t := range transforms {
+		code, err = t.apply(env, code)
+		if err != nil {
+			return "", err
+		}
+	}
+	return code, nil
+}
+
+// ExtractCode returns the lines of code after the `@lc code=start` marker and
+// before the following `@lc code=end` marker, a missing marker extends the
+// region to the start or the end of code
+func ExtractCode(code string) string {
+	lines := strings.Split(code, "\n")
+
+	start, end := 0, len(lines)
+	for i, line := range lines {
+		if strings.Contains(line, codeStartMarker) {
+			start = i + 1
+			break
+		}
+	}
+	for i := start; i < len(lines); i++ {
+		if strings.Contains(lines[i], codeEndMarker) {
+			end = i
+			break
+		}
+	}
+
+	if start == 0 && end == len(lines) {
+		return code
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+// CodeOffset returns the number of lines of code before the `@lc code=start`
+// region, so that line numbers within the region map back to code; it is 0
+// when code has no region
+func CodeOffset(code string) int {
+	for i, line := range strings.Split(code, "\n") {
+		if strings.Contains(line, codeStartMarker) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// ReplaceCode returns content with the region between `@lc code=start` and
+// `@lc code=end` markers replaced by code, or false when content has no such
+// region
+func ReplaceCode(content string, code string) (string, bool) {
+	lines := strings.Split(content, "\n")
+
+	start, end := -1, -1
+	for i, line := range lines {
+		if start < 0 && strings.Contains(line, codeStartMarker) {
+			start = i
+		} else if start >= 0 && strings.Contains(line, codeEndMarker) {
+			end = i
+			break
+		}
+	}
+	if start < 0 || end < 0 {
+		return content, false
+	}
+
+	replaced := append([]string(nil), lines[:start+1]...)
+	replaced = append(replaced, strings.TrimRight(code, "\n"))
+	replaced = append(replaced, lines[end:]...)
+	return strings.Join(replaced, "\n"), true
+}
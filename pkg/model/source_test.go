@@ -0,0 +1,124 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCodeOffset(t *testing.T) {
+	for code, want := range map[string]int{
+		"package main\n\n// @lc code=start\nfunc f() {}\n// @lc code=end\n": 3,
+		"// @lc code=start\nfunc f() {}\n":                                  1,
+		"func f() {}\n":                                                     0,
+	} {
+		if got := CodeOffset(code); got != want {
+			t.Errorf("CodeOffset(%q) = %d, want %d", code, got, want)
+		}
+	}
+}
+
+func TestMatchSourceCodePath(t *testing.T) {
+	for _, tt := range []struct {
+		pattern string
+		fp      string
+		want    SourceHeader
+		ok      bool
+	}{
+		{"$questionID_$questionSlug.$ext", "0001_two-sum.go", SourceHeader{ID: 1, Slug: "two-sum"}, true},
+		{"$questionID_$questionSlug.$ext", "1234_lru-cache.py", SourceHeader{ID: 1234, Slug: "lru-cache"}, true},
+		{"src/$questionID/solution.$ext", "src/0009/solution.cpp", SourceHeader{ID: 9}, true},
+		{"src/$questionSlug/main.go", "src/two-sum/main.go", SourceHeader{Slug: "two-sum"}, true},
+		{"$questionID_$questionSlug.$ext", "two-sum.go", SourceHeader{}, false},
+		{"src/$questionID/solution.$ext", "other/0001/solution.go", SourceHeader{}, false},
+		{"src/solution.go", "src/solution.go", SourceHeader{}, false},
+		{"", "0001_two-sum.go", SourceHeader{}, false},
+	} {
+		got, ok := MatchSourceCodePath(tt.pattern, tt.fp)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("MatchSourceCodePath(%q, %q) = %+v, %v, want %+v, %v", tt.pattern, tt.fp, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestReadSourceHeader(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		content string
+		want    SourceHeader
+		wantErr bool
+	}{
+		{"go", "// @lc id=1 slug=two-sum lang=golang\n\npackage main\n", SourceHeader{ID: 1, Slug: "two-sum", Lang: "golang"}, false},
+		{"python", "# @lc id=9 slug=palindrome-number lang=python3\n", SourceHeader{ID: 9, Slug: "palindrome-number", Lang: "python3"}, false},
+		{"partial", "// @lc slug=two-sum\n", SourceHeader{Slug: "two-sum"}, false},
+		{"stray fields", "// @lc id=1 junk lang\n", SourceHeader{ID: 1}, false},
+		{"missing", "package main\n\nfunc main() {}\n", SourceHeader{}, false},
+		{"markers only", "// @lc code=start\nfunc f() {}\n// @lc code=end\n", SourceHeader{}, false},
+		{"malformed id", "// @lc id=one slug=two-sum\n", SourceHeader{}, true},
+		{"too far", strings.Repeat("\n", sourceHeaderLines) + "// @lc id=1\n", SourceHeader{}, false},
+	} {
+		fp := filepath.Join(t.TempDir(), "solution")
+		if err := os.WriteFile(fp, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		got, err := ReadSourceHeader(fp)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: error %v, want error %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("%s: header %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := ReadSourceHeader(filepath.Join(t.TempDir(), "missing.go")); err == nil {
+		t.Error("missing file: no error")
+	}
+}
+
+func TestExtractCode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		code string
+		want string
+	}{
+		{"region", "package main\n// @lc code=start\nfunc f() {}\n// @lc code=end\nfunc main() {}\n", "func f() {}"},
+		{"missing markers", "func f() {}\n", "func f() {}\n"},
+		{"unterminated", "package main\n// @lc code=start\nfunc f() {}\n", "func f() {}\n"},
+		{"end only", "func f() {}\n// @lc code=end\nfunc main() {}\n", "func f() {}"},
+		{"empty region", "// @lc code=start\n// @lc code=end\n", ""},
+	} {
+		if got := ExtractCode(tt.code); got != tt.want {
+			t.Errorf("%s: ExtractCode = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestReplaceCode(t *testing.T) {
+	for _, content := range []string{
+		"func f() {}\n",
+		"// @lc code=start\nfunc f() {}\n",
+		"func f() {}\n// @lc code=end\n",
+	} {
+		if got, ok := ReplaceCode(content, "func g() {}"); ok || got != content {
+			t.Errorf("ReplaceCode(%q) = %q, %v, want content unchanged", content, got, ok)
+		}
+	}
+
+	content := "package main\n\n// @lc code=start\nfunc f() {}\n\nfunc g() {}\n// @lc code=end\n\nfunc main() {}\n"
+	if got, ok := ReplaceCode(content, ExtractCode(content)); !ok || got != content {
+		t.Errorf("ReplaceCode(ExtractCode) = %q, %v, want %q", got, ok, content)
+	}
+
+	replaced, ok := ReplaceCode(content, "func h() {}\n")
+	if !ok {
+		t.Fatal("ReplaceCode found no region")
+	}
+	if got := ExtractCode(replaced); got != "func h() {}" {
+		t.Errorf("ExtractCode(ReplaceCode) = %q, want the new code", got)
+	}
+	if !strings.HasPrefix(replaced, "package main\n\n// @lc code=start\n") || !strings.HasSuffix(replaced, "// @lc code=end\n\nfunc main() {}\n") {
+		t.Errorf("ReplaceCode changed the scaffold:\n%s", replaced)
+	}
+}
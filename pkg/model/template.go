@@ -16,8 +16,8 @@ type FileTemplate struct {
 	MarkdownTemplate *template.Template
 }
 
-// GetFileTemplate returns a basic API template struct based on local template config
-func GetFileTemplate(pd ProblemDetail) (*FileTemplate, error) {
+// readFileTemplate reads the local template config with its placeholders
+func readFileTemplate() (*FileTemplate, error) {
 	t := FileTemplate{}
 
 	file, err := os.ReadFile(utils.TemplateConfigPath)
@@ -26,28 +26,47 @@ func GetFileTemplate(pd ProblemDetail) (*FileTemplate, error) {
 	}
 
 	err = json.Unmarshal([]byte(file), &t)
+	return &t, err
+}
+
+// GetFileTemplate returns a basic API template struct based on local template config
+func GetFileTemplate(pd ProblemDetail) (*FileTemplate, error) {
+	return getFileTemplate(pd, "1")
+}
+
+// getFileTemplate fills the local template config for problem pd, with
+// `$submissionID` standing for submissionID
+func getFileTemplate(pd ProblemDetail, submissionID string) (*FileTemplate, error) {
+	t, err := readFileTemplate()
 	if err != nil {
-		return &t, err
+		return t, err
 	}
 
-	numLen := len(pd.QuestionFrontendID)
-	zeroPad := strings.Repeat("0", 4-numLen)
+	id := paddedID(pd.QuestionFrontendID)
 	if t.MarkdownPath != "" {
-		t.MarkdownPath = strings.ReplaceAll(t.MarkdownPath, "$questionID", zeroPad+pd.QuestionFrontendID)
+		t.MarkdownPath = strings.ReplaceAll(t.MarkdownPath, "$questionID", id)
 		t.MarkdownPath = strings.ReplaceAll(t.MarkdownPath, "$questionSlug", pd.TitleSlug)
 	}
 
 	if t.SourceCodePath != "" {
-		t.SourceCodePath = strings.ReplaceAll(t.SourceCodePath, "$questionID", zeroPad+pd.QuestionFrontendID)
+		t.SourceCodePath = strings.ReplaceAll(t.SourceCodePath, "$questionID", id)
 		t.SourceCodePath = strings.ReplaceAll(t.SourceCodePath, "$questionSlug", pd.TitleSlug)
-		t.SourceCodePath = strings.ReplaceAll(t.SourceCodePath, "$submissionID", "1")
+		t.SourceCodePath = strings.ReplaceAll(t.SourceCodePath, "$submissionID", submissionID)
 	}
 
 	md, err := template.ParseFiles(utils.MarkdownTemplatePath)
 	if err != nil {
-		return &t, err
+		return t, err
 	}
 	t.MarkdownTemplate = md
 
-	return &t, nil
+	return t, nil
+}
+
+// paddedID zero pads a question ID to 4 digits
+func paddedID(id string) string {
+	if len(id) >= 4 {
+		return id
+	}
+	return strings.Repeat("0", 4-len(id)) + id
 }
@@ -0,0 +1,227 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/bundle"
+)
+
+// Transform types of the pre-submit pipeline
+const (
+	// TransformStripMarkers keeps the region between `@lc code=start/end`
+	TransformStripMarkers = "strip_markers"
+	// TransformGofmt formats Go code
+	TransformGofmt = "gofmt"
+	// TransformRemoveLines drops the lines matching Pattern
+	TransformRemoveLines = "remove_lines"
+	// TransformBundle inlines the local Go packages of `goPackages` into the
+	// `@lc code` region, along with the imports declared above it
+	TransformBundle = "bundle"
+	// TransformCommand pipes the code through Command
+	TransformCommand = "command"
+)
+
+// Transform is a step of the pipeline turning a source file into the code
+// sent to the judge
+type Transform struct {
+	Type string `json:"type"`
+	// Pattern is the regular expression of lines dropped by remove_lines
+	Pattern string `json:"pattern,omitempty"`
+	// Command is run by command with the code on stdin, its stdout replaces
+	// the code
+	Command []string `json:"command,omitempty"`
+}
+
+// defaultTransforms is the pipeline of languages without configured transforms,
+// Go files are bundled whole before their region is kept as the imports of
+// the scaffold sit above it
+func defaultTransforms(lang string) []Transform {
+	if lang == "golang" {
+		return []Transform{{Type: TransformBundle}, {Type: TransformStripMarkers}}
+	}
+	return []Transform{{Type: TransformStripMarkers}}
+}
+
+// transformEnv is what a transform knows about the code it is given
+type transformEnv struct {
+	fp     string
+	lang   string
+	config *Config
+}
+
+// apply runs the transform on code
+func (t Transform) apply(env transformEnv, code string) (string, error) {
+	switch t.Type {
+	case TransformStripMarkers:
+		return ExtractCode(code), nil
+	case TransformGofmt:
+		formatted, err := format.Source([]byte(code))
+		if err != nil {
+			return "", err
+		}
+		return string(formatted), nil
+	case TransformRemoveLines:
+		re, err := regexp.Compile(t.Pattern)
+		if err != nil {
+			return "", err
+		}
+		var lines []string
+		for _, line := range strings.Split(code, "\n") {
+			if !re.MatchString(line) {
+				lines = append(lines, line)
+			}
+		}
+		return strings.Join(lines, "\n"), nil
+	case TransformBundle:
+		return bundleRegion(env, code)
+	case TransformCommand:
+		return t.run(env, code)
+	default:
+		return "", fmt.Errorf("unknown transform type %q", t.Type)
+	}
+}
+
+// run pipes code through the external command of t, from the directory of
+// the source file with LC_FILE and LC_LANG set
+func (t Transform) run(env transformEnv, code string) (string, error) {
+	if len(t.Command) == 0 {
+		return "", fmt.Errorf("transform %s has no command", t.Type)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(t.Command[0], t.Command[1:]...)
+	cmd.Dir = filepath.Dir(env.fp)
+	cmd.Env = append(os.Environ(), "LC_FILE="+env.fp, "LC_LANG="+env.lang)
+	cmd.Stdin = strings.NewReader(code)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("transform command %s failed: %v\n%s", strings.Join(t.Command, " "), err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// bundleRegion bundles the `@lc code` region of the Go source code together
+// with the imports it uses from above it, which are moved into the region so
+// that it stands alone once extracted; code without region is bundled whole
+func bundleRegion(env transformEnv, code string) (string, error) {
+	lines := strings.Split(code, "\n")
+	start, end := -1, -1
+	for i, line := range lines {
+		if start < 0 && strings.Contains(line, codeStartMarker) {
+			start = i
+		} else if start >= 0 && strings.Contains(line, codeEndMarker) {
+			end = i
+			break
+		}
+	}
+	if start < 0 || end < 0 {
+		return bundle.Bundle(env.fp, code, env.config.GoPackages)
+	}
+
+	region := strings.Join(lines[start+1:end], "\n")
+	imports, before := hoistImports(strings.Join(lines[:start], "\n"), region)
+	if imports != "" {
+		region = imports + "\n\n" + strings.TrimLeft(region, "\n")
+	}
+
+	bundled, err := bundle.Bundle(env.fp, region, env.config.GoPackages)
+	if err != nil {
+		return "", err
+	}
+
+	parts := []string{before, lines[start], strings.Trim(bundled, "\n")}
+	parts = append(parts, lines[end:]...)
+	return strings.Join(parts, "\n"), nil
+}
+
+// hoistImports removes from the Go source prefix src the imports referenced
+// by region, returning them as an import declaration along with what is left
+// of src
+func hoistImports(src string, region string) (string, string) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "", src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return "", src
+	}
+	used := packageRefs(region)
+
+	type cut struct{ from, to int }
+	var specs []string
+	var cuts []cut
+	for _, decl := range f.Decls {
+		d, ok := decl.(*ast.GenDecl)
+		if !ok || d.Tok != token.IMPORT {
+			continue
+		}
+
+		var moved []ast.Spec
+		for _, spec := range d.Specs {
+			if used == nil || used[importName(spec.(*ast.ImportSpec))] {
+				moved = append(moved, spec)
+			}
+		}
+		if len(moved) == len(d.Specs) {
+			cuts = append(cuts, cut{fset.Position(d.Pos()).Offset, fset.Position(d.End()).Offset})
+		} else {
+			for _, spec := range moved {
+				cuts = append(cuts, cut{fset.Position(spec.Pos()).Offset, fset.Position(spec.End()).Offset})
+			}
+		}
+		for _, spec := range moved {
+			specs = append(specs, src[fset.Position(spec.Pos()).Offset:fset.Position(spec.End()).Offset])
+		}
+	}
+	if len(specs) == 0 {
+		return "", src
+	}
+
+	rest := src
+	for i := len(cuts) - 1; i >= 0; i-- {
+		rest = rest[:cuts[i].from] + rest[cuts[i].to:]
+	}
+	return "import (\n\t" + strings.Join(specs, "\n\t") + "\n)", rest
+}
+
+// packageRefs returns the names qualifying identifiers in the Go declarations
+// of region, or nil when region does not parse
+func packageRefs(region string) map[string]bool {
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package main\n"+region, 0)
+	if err != nil {
+		return nil
+	}
+
+	refs := map[string]bool{".": true}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if id, ok := sel.X.(*ast.Ident); ok && id.Obj == nil {
+				refs[id.Name] = true
+			}
+		}
+		return true
+	})
+	return refs
+}
+
+// importName is the name an import spec is referred to by, assuming the
+// package is named after the last element of its path
+func importName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	path, _ := strconv.Unquote(spec.Path.Value)
+	return path[strings.LastIndex(path, "/")+1:]
+}
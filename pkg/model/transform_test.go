@@ -0,0 +1,42 @@
+package model
+
+import "testing"
+
+func TestHoistImports(t *testing.T) {
+	before := `package main
+
+import (
+	"fmt"
+	"sort"
+
+	uf "example.com/lib/uf"
+)
+`
+	region := `func f(xs []int) int { sort.Ints(xs); return uf.New(len(xs)).Find(0) }`
+
+	imports, rest := hoistImports(before, region)
+	if want := "import (\n\t\"sort\"\n\tuf \"example.com/lib/uf\"\n)"; imports != want {
+		t.Errorf("imports = %q, want %q", imports, want)
+	}
+	if want := "package main\n\nimport (\n\t\"fmt\"\n\t\n\n\t\n)\n"; rest != want {
+		t.Errorf("rest = %q, want %q", rest, want)
+	}
+}
+
+func TestHoistImportsWholeDeclaration(t *testing.T) {
+	imports, rest := hoistImports("package main\n\nimport \"sort\"\n", "func f(xs []int) { sort.Ints(xs) }")
+	if imports != "import (\n\t\"sort\"\n)" {
+		t.Errorf("imports = %q", imports)
+	}
+	if rest != "package main\n\n\n" {
+		t.Errorf("rest = %q", rest)
+	}
+}
+
+func TestHoistImportsUnused(t *testing.T) {
+	before := "package main\n\nimport \"fmt\"\n"
+	imports, rest := hoistImports(before, "func f() int { return 1 }")
+	if imports != "" || rest != before {
+		t.Errorf("got (%q, %q), want nothing hoisted", imports, rest)
+	}
+}
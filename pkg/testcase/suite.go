@@ -0,0 +1,159 @@
+// Package testcase manages the custom test case library of each problem,
+// stored in the workspace as tests/<id>.txt
+//
+// Cases are separated by blank lines and hold one input line per solution
+// parameter. A line starting with `=> ` records the expected answer and a
+// line starting with `# ` records where the case comes from:
+//
+//	# source: submission 1234
+//	[3,2,4]
+//	6
+//	=> [1,2]
+package testcase
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+const (
+	expectedPrefix = "=> "
+	sourcePrefix   = "# source: "
+)
+
+// Case is a single custom test case of a problem
+type Case struct {
+	Input    string
+	Expected string
+	Source   string
+}
+
+// Suite is the test case library of a problem
+type Suite struct {
+	ProblemID int
+	Cases     []Case
+}
+
+// Path returns the workspace file of the test case library of problem id
+func Path(id int) string {
+	return filepath.Join(utils.TestCaseDir, fmt.Sprintf("%d.txt", id))
+}
+
+// Load reads the test case library of problem id, a missing file yields an
+// empty suite
+func Load(id int) (*Suite, error) {
+	s := &Suite{ProblemID: id}
+
+	f, err := os.Open(Path(id))
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var c Case
+	var input []string
+	flush := func() {
+		if len(input) > 0 {
+			c.Input = strings.Join(input, "\n")
+			s.Cases = append(s.Cases, c)
+		}
+		c = Case{}
+		input = nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case strings.TrimSpace(line) == "":
+			flush()
+		case strings.HasPrefix(line, sourcePrefix):
+			c.Source = strings.TrimPrefix(line, sourcePrefix)
+		case strings.HasPrefix(line, expectedPrefix):
+			c.Expected = strings.TrimPrefix(line, expectedPrefix)
+		case strings.HasPrefix(line, "#"):
+		default:
+			input = append(input, line)
+		}
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Save writes the suite back to its workspace file
+func (s *Suite) Save() error {
+	var b strings.Builder
+	for i, c := range s.Cases {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if c.Source != "" {
+			b.WriteString(sourcePrefix + c.Source + "\n")
+		}
+		b.WriteString(c.Input + "\n")
+		if c.Expected != "" {
+			b.WriteString(expectedPrefix + c.Expected + "\n")
+		}
+	}
+
+	p := Path(s.ProblemID)
+	err := os.MkdirAll(filepath.Dir(p), os.ModePerm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, []byte(b.String()), 0644)
+}
+
+// Find returns the index of the case with the given input, or -1
+func (s *Suite) Find(input string) int {
+	for i, c := range s.Cases {
+		if c.Input == input {
+			return i
+		}
+	}
+	return -1
+}
+
+// Add appends c unless a case with the same input is already stored, in which
+// case a missing expected answer is filled in; it reports whether c was new
+func (s *Suite) Add(c Case) bool {
+	if i := s.Find(c.Input); i >= 0 {
+		if s.Cases[i].Expected == "" {
+			s.Cases[i].Expected = c.Expected
+		}
+		return false
+	}
+	s.Cases = append(s.Cases, c)
+	return true
+}
+
+// Remove deletes the case numbered n, starting from 1
+func (s *Suite) Remove(n int) error {
+	if n < 1 || n > len(s.Cases) {
+		return fmt.Errorf("problem %d has no test case %d", s.ProblemID, n)
+	}
+	s.Cases = append(s.Cases[:n-1], s.Cases[n:]...)
+	return nil
+}
+
+// Inputs returns the input of every case in order
+func (s *Suite) Inputs() []string {
+	inputs := make([]string, 0, len(s.Cases))
+	for _, c := range s.Cases {
+		inputs = append(inputs, c.Input)
+	}
+	return inputs
+}
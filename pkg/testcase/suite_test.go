@@ -0,0 +1,98 @@
+package testcase
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/utils"
+)
+
+func useTestCaseDir(t *testing.T) {
+	dir := utils.TestCaseDir
+	utils.TestCaseDir = t.TempDir()
+	t.Cleanup(func() { utils.TestCaseDir = dir })
+}
+
+func TestLoad(t *testing.T) {
+	useTestCaseDir(t)
+	content := "# source: submission 1234\r\n[3,2,4]\r\n6\r\n=> [1,2]\r\n\r\n\r\n" +
+		"# a note\n[3,3]\n6\n\n" +
+		"[1,2]\n3\n=> [0,1]\n"
+	if err := os.MkdirAll(utils.TestCaseDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(1), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Case{
+		{Input: "[3,2,4]\n6", Expected: "[1,2]", Source: "submission 1234"},
+		{Input: "[3,3]\n6"},
+		{Input: "[1,2]\n3", Expected: "[0,1]"},
+	}
+	if !reflect.DeepEqual(s.Cases, want) {
+		t.Errorf("Load = %+v, want %+v", s.Cases, want)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	useTestCaseDir(t)
+	s, err := Load(42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.ProblemID != 42 || len(s.Cases) != 0 {
+		t.Errorf("Load of a missing library = %+v, want an empty suite", s)
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	useTestCaseDir(t)
+	s := &Suite{ProblemID: 7, Cases: []Case{
+		{Input: "[1]\n1", Expected: "[0]", Source: "submission 1, wrong_answer"},
+		{Input: "[2]\n2"},
+	}}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, s) {
+		t.Errorf("Load after Save = %+v, want %+v", loaded, s)
+	}
+}
+
+func TestAddAndRemove(t *testing.T) {
+	s := &Suite{ProblemID: 1}
+	if !s.Add(Case{Input: "a"}) || !s.Add(Case{Input: "b"}) {
+		t.Fatal("Add of new inputs reported them as stored")
+	}
+	if s.Add(Case{Input: "a", Expected: "1"}) {
+		t.Error("Add of a stored input reported it as new")
+	}
+	if s.Cases[0].Expected != "1" {
+		t.Errorf("Add did not fill in the missing expected answer: %+v", s.Cases[0])
+	}
+	s.Add(Case{Input: "a", Expected: "2"})
+	if s.Cases[0].Expected != "1" {
+		t.Errorf("Add overwrote the expected answer: %+v", s.Cases[0])
+	}
+
+	if err := s.Remove(3); err == nil {
+		t.Error("Remove of a missing case succeeded")
+	}
+	if err := s.Remove(1); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.Inputs(), []string{"b"}) {
+		t.Errorf("Inputs after Remove = %q, want [b]", s.Inputs())
+	}
+}
@@ -5,27 +5,50 @@ import (
 	"os"
 )
 
+// BaseURL is the leetcode site all API URLs derive from, overridable with
+// LC_BASE_URL to target a local fake server
+var BaseURL = getEnv("LC_BASE_URL", "https://leetcode.com")
+
 // URLs supported by leetcode api
-const (
-	BaseURL           = "https://leetcode.com"
-	GraphQLURL        = "https://leetcode.com/graphql"
-	LoginURL          = "https://leetcode.com/accounts/login/"
-	ProblemListingURL = "https://leetcode.com/api/problems/$category/"
-	ProblemQueryURL   = "https://leetcode.com/problems/api/filter-questions/$query"
-	ProblemURL        = "https://leetcode.com/problems/$slug/description/"
-	SubmitURL         = "https://leetcode.com/problems/$slug/submit/"
-	SubmitRefererURL  = "https://leetcode.com/problems/$slug/submissions/"
-	InterpretURL      = "https://leetcode.com/problems/$slug/interpret_solution/"
-	VerifyURL         = "https://leetcode.com/submissions/detail/$id/check/"
+var (
+	GraphQLURL        = BaseURL + "/graphql"
+	LoginURL          = BaseURL + "/accounts/login/"
+	ProblemListingURL = BaseURL + "/api/problems/$category/"
+	ProblemQueryURL   = BaseURL + "/problems/api/filter-questions/$query"
+	ProblemURL        = BaseURL + "/problems/$slug/description/"
+	SubmitURL         = BaseURL + "/problems/$slug/submit/"
+	SubmitRefererURL  = BaseURL + "/problems/$slug/submissions/"
+	InterpretURL      = BaseURL + "/problems/$slug/interpret_solution/"
+	VerifyURL         = BaseURL + "/submissions/detail/$id/check/"
 )
 
+// ConfigDir is the local configuration directory, overridable with LC_CONFIG_DIR
+var ConfigDir = getEnv("LC_CONFIG_DIR", fmt.Sprintf("%s/.lc/leetcode", os.Getenv("HOME")))
+
 // Local Path for configuration
 var (
-	AuthConfigPath       = fmt.Sprintf("%s/.lc/leetcode/user.json", os.Getenv("HOME"))
-	TemplateConfigPath   = fmt.Sprintf("%s/.lc/leetcode/template.json", os.Getenv("HOME"))
-	MarkdownTemplatePath = fmt.Sprintf("%s/.lc/leetcode/template.md", os.Getenv("HOME"))
+	AuthConfigPath       = ConfigDir + "/user.json"
+	ConfigPath           = ConfigDir + "/config.json"
+	TemplateConfigPath   = ConfigDir + "/template.json"
+	MarkdownTemplatePath = ConfigDir + "/template.md"
 )
 
+// DataDir holds the local records of the cli, overridable with LC_DATA_DIR
+var DataDir = getEnv("LC_DATA_DIR", ConfigDir)
+
+// JournalPath is the append-only log of judged runs
+var JournalPath = DataDir + "/journal.jsonl"
+
+// BulkStateDir holds the progress of interrupted bulk operations
+var BulkStateDir = DataDir + "/bulk"
+
+// ProblemCacheDir holds the problem details fetched from leetcode
+var ProblemCacheDir = DataDir + "/cache/problems"
+
+// TestCaseDir is the workspace directory of per-problem test case libraries,
+// overridable with LC_TESTS_DIR
+var TestCaseDir = getEnv("LC_TESTS_DIR", "tests")
+
 // GraphQL related query, operation string
 const (
 	QuestionDataQuery = `
@@ -75,6 +98,7 @@ const (
 		        }
 		        status
 		        sampleTestCase
+		        exampleTestcases
 		        metaData
 		        judgerAvailable
 		        judgeType
@@ -90,3 +114,62 @@ const (
 		}`
 	QuestionDataOperation = "questionData"
 )
+
+// GraphQL queries of the submissions of the signed-in user
+const (
+	QuestionSubmissionListQuery = `
+		query questionSubmissionList($offset: Int!, $limit: Int!, $lastKey: String, $questionSlug: String!) {
+		    questionSubmissionList(offset: $offset, limit: $limit, lastKey: $lastKey, questionSlug: $questionSlug) {
+		        lastKey
+		        hasNext
+		        submissions {
+		            id
+		            title
+		            titleSlug
+		            status
+		            statusDisplay
+		            lang
+		            langName
+		            runtime
+		            memory
+		            timestamp
+		            isPending
+		        }
+		    }
+		}`
+	QuestionSubmissionListOperation = "questionSubmissionList"
+
+	SubmissionDetailsQuery = `
+		query submissionDetails($submissionId: Int!) {
+		    submissionDetails(submissionId: $submissionId) {
+		        runtime
+		        runtimeDisplay
+		        runtimePercentile
+		        runtimeDistribution
+		        memory
+		        memoryDisplay
+		        memoryPercentile
+		        code
+		        timestamp
+		        statusCode
+		        lang {
+		            name
+		            verboseName
+		        }
+		        question {
+		            questionId
+		            questionFrontendId
+		            titleSlug
+		        }
+		        runtimeError
+		        compileError
+		        lastTestcase
+		        codeOutput
+		        expectedOutput
+		        totalCorrect
+		        totalTestcases
+		        stdOutput
+		    }
+		}`
+	SubmissionDetailsOperation = "submissionDetails"
+)
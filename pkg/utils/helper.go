@@ -1,5 +1,7 @@
 package utils
 
+import "os"
+
 // Contains check if item in list
 func Contains(list []interface{}, item interface{}) bool {
 	for _, v := range list {
@@ -9,3 +11,20 @@ func Contains(list []interface{}, item interface{}) bool {
 	}
 	return false
 }
+
+// FirstNonEmpty returns the first of values that is not empty
+func FirstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func getEnv(key string, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
@@ -0,0 +1,41 @@
+// Command fakeserver runs the lctest fake leetcode server so that the cli can
+// be exercised end-to-end offline:
+//
+//	go run ./scripts/fakeserver -config /tmp/lc
+//	export LC_BASE_URL=... LC_CONFIG_DIR=/tmp/lc   # as printed on startup
+//	lc list && lc show -i 1 -l golang && lc submit -i 1 -f 0001_two-sum.go
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+
+	"github.com/ckidckidckid/leetcode-cli/pkg/lctest"
+)
+
+func main() {
+	dir := flag.String("config", os.TempDir()+"/lctest", "directory to write the fake configuration into")
+	flag.Parse()
+
+	err := lctest.WriteConfig(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s, err := lctest.NewServer()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer s.Close()
+
+	for _, env := range s.Environ(*dir) {
+		fmt.Printf("export %s\n", env)
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt)
+	<-c
+}